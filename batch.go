@@ -0,0 +1,866 @@
+package orc
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// DefaultBatchSize is the number of rows read into a Batch by NextBatch if its
+// Size is not set.
+const DefaultBatchSize = 1024
+
+// Batch holds a batch of rows read by Cursor.NextBatch, with a ColumnVector
+// for each of the columns selected by the Cursor. A Batch is reused by each
+// call to NextBatch, so the values it holds are only valid until the next call.
+type Batch struct {
+	// Size is the maximum number of rows read into the Batch.
+	Size int
+	// Len is the number of rows read into the Batch by the last call to
+	// NextBatch.
+	Len int
+	// Columns holds a ColumnVector for each column selected by the Cursor, in
+	// the order they were selected.
+	Columns []ColumnVector
+	columns []*TypeDescription
+}
+
+// NewBatch returns a new Batch that holds up to size rows.
+func NewBatch(size int) *Batch {
+	return &Batch{
+		Size: size,
+	}
+}
+
+// prepare empties the Batch, creating new ColumnVectors if they do not match
+// the provided columns.
+func (b *Batch) prepare(columns []*TypeDescription) error {
+	b.Len = 0
+	if b.matches(columns) {
+		for _, column := range b.Columns {
+			column.reset()
+		}
+		return nil
+	}
+	vectors := make([]ColumnVector, len(columns))
+	for i, column := range columns {
+		vector, err := newColumnVector(column)
+		if err != nil {
+			return err
+		}
+		vectors[i] = vector
+	}
+	b.Columns = vectors
+	b.columns = columns
+	return nil
+}
+
+// matches returns true if the ColumnVectors of the Batch were created for the
+// provided columns.
+func (b *Batch) matches(columns []*TypeDescription) bool {
+	if len(b.columns) != len(columns) || len(b.Columns) != len(columns) {
+		return false
+	}
+	for i := range columns {
+		if b.columns[i] != columns[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ColumnVector holds the values of a column for the rows of a Batch. The
+// vectors of the columns of a Batch, and the fields of a struct or union
+// column, hold a value for each row. The vectors of the elements of a list
+// column, and the keys and values of a map column, hold the values of every
+// row, which are located using the Offsets of their parent vector.
+type ColumnVector interface {
+	// Len returns the number of values in the vector.
+	Len() int
+	// IsNull returns true if the value at index i is null.
+	IsNull(i int) bool
+	reset()
+	appendNull()
+}
+
+// vector records which values of a ColumnVector are null.
+type vector struct {
+	// Nulls is true for each value that is null, it may be shorter than the
+	// vector, or nil, if the remaining values are not null.
+	Nulls []bool
+}
+
+// IsNull implements the ColumnVector interface.
+func (v *vector) IsNull(i int) bool {
+	return i < len(v.Nulls) && v.Nulls[i]
+}
+
+// LongVector is a ColumnVector of tinyint, smallint, int, bigint and date
+// columns. Dates are held as the number of days since the Unix epoch.
+type LongVector struct {
+	vector
+	Values []int64
+}
+
+// Len implements the ColumnVector interface.
+func (v *LongVector) Len() int {
+	return len(v.Values)
+}
+
+func (v *LongVector) reset() {
+	v.Nulls = v.Nulls[:0]
+	v.Values = v.Values[:0]
+}
+
+func (v *LongVector) appendNull() {
+	v.Nulls = append(v.Nulls, true)
+	v.Values = append(v.Values, 0)
+}
+
+func (v *LongVector) append(value int64) {
+	v.Nulls = append(v.Nulls, false)
+	v.Values = append(v.Values, value)
+}
+
+// DoubleVector is a ColumnVector of float and double columns.
+type DoubleVector struct {
+	vector
+	Values []float64
+}
+
+// Len implements the ColumnVector interface.
+func (v *DoubleVector) Len() int {
+	return len(v.Values)
+}
+
+func (v *DoubleVector) reset() {
+	v.Nulls = v.Nulls[:0]
+	v.Values = v.Values[:0]
+}
+
+func (v *DoubleVector) appendNull() {
+	v.Nulls = append(v.Nulls, true)
+	v.Values = append(v.Values, 0)
+}
+
+func (v *DoubleVector) append(value float64) {
+	v.Nulls = append(v.Nulls, false)
+	v.Values = append(v.Values, value)
+}
+
+// BooleanVector is a ColumnVector of boolean columns.
+type BooleanVector struct {
+	vector
+	Values []bool
+}
+
+// Len implements the ColumnVector interface.
+func (v *BooleanVector) Len() int {
+	return len(v.Values)
+}
+
+func (v *BooleanVector) reset() {
+	v.Nulls = v.Nulls[:0]
+	v.Values = v.Values[:0]
+}
+
+func (v *BooleanVector) appendNull() {
+	v.Nulls = append(v.Nulls, true)
+	v.Values = append(v.Values, false)
+}
+
+func (v *BooleanVector) append(value bool) {
+	v.Nulls = append(v.Nulls, false)
+	v.Values = append(v.Values, value)
+}
+
+// BytesVector is a ColumnVector of string, varchar, char and binary columns.
+// The value at index i is Data[Offsets[i]:Offsets[i+1]].
+type BytesVector struct {
+	vector
+	Offsets []int
+	Data    []byte
+}
+
+// Bytes returns the value at index i.
+func (v *BytesVector) Bytes(i int) []byte {
+	return v.Data[v.Offsets[i]:v.Offsets[i+1]]
+}
+
+// Len implements the ColumnVector interface.
+func (v *BytesVector) Len() int {
+	if len(v.Offsets) == 0 {
+		return 0
+	}
+	return len(v.Offsets) - 1
+}
+
+func (v *BytesVector) reset() {
+	v.Nulls = v.Nulls[:0]
+	v.Offsets = append(v.Offsets[:0], 0)
+	v.Data = v.Data[:0]
+}
+
+func (v *BytesVector) appendNull() {
+	v.Nulls = append(v.Nulls, true)
+	v.Offsets = append(v.Offsets, len(v.Data))
+}
+
+func (v *BytesVector) append(value []byte) {
+	v.Data = append(v.Data, value...)
+	v.Nulls = append(v.Nulls, false)
+	v.Offsets = append(v.Offsets, len(v.Data))
+}
+
+// read appends a value of the length returned by length read from data.
+func (v *BytesVector) read(length IntegerReader, data io.Reader) error {
+	l := int(length.Int())
+	start := len(v.Data)
+	v.Data = append(v.Data, make([]byte, l)...)
+	if _, err := io.ReadFull(data, v.Data[start:]); err != nil {
+		v.Data = v.Data[:start]
+		return err
+	}
+	v.Nulls = append(v.Nulls, false)
+	v.Offsets = append(v.Offsets, len(v.Data))
+	return nil
+}
+
+// trimRight removes any trailing spaces from the last value of the vector.
+func (v *BytesVector) trimRight() {
+	n := v.Len()
+	end := v.Offsets[n]
+	for end > v.Offsets[n-1] && v.Data[end-1] == ' ' {
+		end--
+	}
+	v.Offsets[n] = end
+	v.Data = v.Data[:end]
+}
+
+// DecimalVector is a ColumnVector of decimal columns.
+type DecimalVector struct {
+	vector
+	Values []Decimal
+}
+
+// Len implements the ColumnVector interface.
+func (v *DecimalVector) Len() int {
+	return len(v.Values)
+}
+
+func (v *DecimalVector) reset() {
+	v.Nulls = v.Nulls[:0]
+	v.Values = v.Values[:0]
+}
+
+func (v *DecimalVector) appendNull() {
+	v.Nulls = append(v.Nulls, true)
+	v.Values = append(v.Values, Decimal{})
+}
+
+func (v *DecimalVector) append(value Decimal) {
+	v.Nulls = append(v.Nulls, false)
+	v.Values = append(v.Values, value)
+}
+
+// TimestampVector is a ColumnVector of timestamp columns.
+type TimestampVector struct {
+	vector
+	Values []time.Time
+}
+
+// Len implements the ColumnVector interface.
+func (v *TimestampVector) Len() int {
+	return len(v.Values)
+}
+
+func (v *TimestampVector) reset() {
+	v.Nulls = v.Nulls[:0]
+	v.Values = v.Values[:0]
+}
+
+func (v *TimestampVector) appendNull() {
+	v.Nulls = append(v.Nulls, true)
+	v.Values = append(v.Values, time.Time{})
+}
+
+func (v *TimestampVector) append(value time.Time) {
+	v.Nulls = append(v.Nulls, false)
+	v.Values = append(v.Values, value)
+}
+
+// ListVector is a ColumnVector of list columns. The elements of the list at
+// index i are Child[Offsets[i]:Offsets[i+1]].
+type ListVector struct {
+	vector
+	Offsets []int
+	Child   ColumnVector
+}
+
+// Len implements the ColumnVector interface.
+func (v *ListVector) Len() int {
+	if len(v.Offsets) == 0 {
+		return 0
+	}
+	return len(v.Offsets) - 1
+}
+
+func (v *ListVector) reset() {
+	v.Nulls = v.Nulls[:0]
+	v.Offsets = append(v.Offsets[:0], 0)
+	v.Child.reset()
+}
+
+func (v *ListVector) appendNull() {
+	v.Nulls = append(v.Nulls, true)
+	v.Offsets = append(v.Offsets, v.Child.Len())
+}
+
+// MapVector is a ColumnVector of map columns. The entries of the map at index
+// i are Keys[Offsets[i]:Offsets[i+1]] and Values[Offsets[i]:Offsets[i+1]].
+type MapVector struct {
+	vector
+	Offsets []int
+	Keys    ColumnVector
+	Values  ColumnVector
+}
+
+// Len implements the ColumnVector interface.
+func (v *MapVector) Len() int {
+	if len(v.Offsets) == 0 {
+		return 0
+	}
+	return len(v.Offsets) - 1
+}
+
+func (v *MapVector) reset() {
+	v.Nulls = v.Nulls[:0]
+	v.Offsets = append(v.Offsets[:0], 0)
+	v.Keys.reset()
+	v.Values.reset()
+}
+
+func (v *MapVector) appendNull() {
+	v.Nulls = append(v.Nulls, true)
+	v.Offsets = append(v.Offsets, v.Keys.Len())
+}
+
+// StructVector is a ColumnVector of struct columns. Fields holds a vector for
+// each field of the struct in the order they appear in the schema, the fields
+// of a null struct are null.
+type StructVector struct {
+	vector
+	Fields []ColumnVector
+	names  []string
+}
+
+// Len implements the ColumnVector interface.
+func (v *StructVector) Len() int {
+	if len(v.Fields) > 0 {
+		return v.Fields[0].Len()
+	}
+	return len(v.Nulls)
+}
+
+func (v *StructVector) reset() {
+	v.Nulls = v.Nulls[:0]
+	for _, field := range v.Fields {
+		field.reset()
+	}
+}
+
+func (v *StructVector) appendNull() {
+	v.Nulls = append(v.Nulls, true)
+	for _, field := range v.Fields {
+		field.appendNull()
+	}
+}
+
+// UnionVector is a ColumnVector of union columns. Tags holds the tag of the
+// value at each index, which is held at the same index by the vector of
+// Children with that tag. The other vectors of Children are null at that index.
+type UnionVector struct {
+	vector
+	Tags     []int
+	Children []ColumnVector
+}
+
+// Len implements the ColumnVector interface.
+func (v *UnionVector) Len() int {
+	return len(v.Tags)
+}
+
+func (v *UnionVector) reset() {
+	v.Nulls = v.Nulls[:0]
+	v.Tags = v.Tags[:0]
+	for _, child := range v.Children {
+		child.reset()
+	}
+}
+
+func (v *UnionVector) appendNull() {
+	v.Nulls = append(v.Nulls, true)
+	v.Tags = append(v.Tags, 0)
+	for _, child := range v.Children {
+		child.appendNull()
+	}
+}
+
+// appendTag appends a value with the provided tag, appending null to the
+// vectors of the other children.
+func (v *UnionVector) appendTag(tag int) error {
+	if tag >= len(v.Children) {
+		return fmt.Errorf("unexpected tag offset: %v expected < %v", tag, len(v.Children))
+	}
+	v.Nulls = append(v.Nulls, false)
+	v.Tags = append(v.Tags, tag)
+	for i, child := range v.Children {
+		if i != tag {
+			child.appendNull()
+		}
+	}
+	return nil
+}
+
+// newColumnVector returns an empty ColumnVector for the column described by td.
+func newColumnVector(td *TypeDescription) (ColumnVector, error) {
+	switch category := td.getCategory(); category {
+	case CategoryBoolean:
+		return &BooleanVector{}, nil
+	case CategoryByte, CategoryShort, CategoryInt, CategoryLong, CategoryDate:
+		return &LongVector{}, nil
+	case CategoryFloat, CategoryDouble:
+		return &DoubleVector{}, nil
+	case CategoryString, CategoryVarchar, CategoryChar, CategoryBinary:
+		return &BytesVector{Offsets: []int{0}, Data: []byte{}}, nil
+	case CategoryDecimal:
+		return &DecimalVector{}, nil
+	case CategoryTimestamp, CategoryTimestampInstant:
+		return &TimestampVector{}, nil
+	case CategoryList:
+		child, err := newColumnVector(td.children[0])
+		if err != nil {
+			return nil, err
+		}
+		return &ListVector{Offsets: []int{0}, Child: child}, nil
+	case CategoryMap:
+		keys, err := newColumnVector(td.children[0])
+		if err != nil {
+			return nil, err
+		}
+		values, err := newColumnVector(td.children[1])
+		if err != nil {
+			return nil, err
+		}
+		return &MapVector{Offsets: []int{0}, Keys: keys, Values: values}, nil
+	case CategoryStruct:
+		fields, err := newColumnVectors(td.children)
+		if err != nil {
+			return nil, err
+		}
+		return &StructVector{Fields: fields, names: td.fieldNames}, nil
+	case CategoryUnion:
+		children, err := newColumnVectors(td.children)
+		if err != nil {
+			return nil, err
+		}
+		return &UnionVector{Children: children}, nil
+	default:
+		return nil, fmt.Errorf("unsupported type: %s", category)
+	}
+}
+
+func newColumnVectors(tds []*TypeDescription) ([]ColumnVector, error) {
+	vectors := make([]ColumnVector, len(tds))
+	for i, td := range tds {
+		vector, err := newColumnVector(td)
+		if err != nil {
+			return nil, err
+		}
+		vectors[i] = vector
+	}
+	return vectors, nil
+}
+
+// presenceReader is implemented by TreeReaders that read a present stream.
+type presenceReader interface {
+	IsPresent() bool
+}
+
+// readVector appends the current value of r, once Next has returned true, to
+// v without allocating an interface{} for it.
+func readVector(r TreeReader, v ColumnVector) error {
+	if c, ok := r.(*CharTreeReader); ok {
+		if err := readVector(c.StringTreeReader, v); err != nil {
+			return err
+		}
+		if !v.IsNull(v.Len() - 1) {
+			v.(*BytesVector).trimRight()
+		}
+		return nil
+	}
+	if p, ok := r.(presenceReader); ok && !p.IsPresent() {
+		v.appendNull()
+		return nil
+	}
+	switch r := r.(type) {
+	case *BooleanTreeReader:
+		if v, ok := v.(*BooleanVector); ok {
+			v.append(r.Bool())
+			return nil
+		}
+	case *ByteTreeReader:
+		if v, ok := v.(*LongVector); ok {
+			v.append(int64(int8(r.Byte())))
+			return nil
+		}
+	case *IntegerTreeReader:
+		if v, ok := v.(*LongVector); ok {
+			v.append(r.Int())
+			return nil
+		}
+	case *DateTreeReader:
+		if v, ok := v.(*LongVector); ok {
+			v.append(r.Int())
+			return nil
+		}
+	case *FloatTreeReader:
+		if v, ok := v.(*DoubleVector); ok {
+			if r.bytesPerValue == 4 {
+				v.append(float64(r.Float()))
+			} else {
+				v.append(float64(r.Double()))
+			}
+			return r.err
+		}
+	case *StringDirectTreeReader:
+		if v, ok := v.(*BytesVector); ok {
+			return v.read(r.length, r.data)
+		}
+	case *StringDictionaryTreeReader:
+		if v, ok := v.(*BytesVector); ok {
+			v.append(r.bytes())
+			return r.err
+		}
+	case *BinaryTreeReader:
+		if v, ok := v.(*BytesVector); ok {
+			return v.read(r.length, r.data)
+		}
+	case *DecimalTreeReader:
+		if v, ok := v.(*DecimalVector); ok {
+			v.append(r.Decimal())
+			return nil
+		}
+	case *TimestampTreeReader:
+		if v, ok := v.(*TimestampVector); ok {
+			v.append(r.Timestamp())
+			return nil
+		}
+	case *ListTreeReader:
+		if v, ok := v.(*ListVector); ok {
+			l := int(r.length.Int())
+			for i := 0; i < l; i++ {
+				if !r.value.Next() {
+					return nextErr(r.value)
+				}
+				if err := readVector(r.value, v.Child); err != nil {
+					return err
+				}
+			}
+			v.Nulls = append(v.Nulls, false)
+			v.Offsets = append(v.Offsets, v.Child.Len())
+			return nil
+		}
+	case *MapTreeReader:
+		if v, ok := v.(*MapVector); ok {
+			l := int(r.length.Int())
+			for i := 0; i < l; i++ {
+				if !r.key.Next() {
+					r.err = nextErr(r.key)
+					return r.err
+				}
+				if !r.value.Next() {
+					r.err = nextErr(r.value)
+					return r.err
+				}
+				if err := readVector(r.key, v.Keys); err != nil {
+					return err
+				}
+				if err := readVector(r.value, v.Values); err != nil {
+					return err
+				}
+			}
+			v.Nulls = append(v.Nulls, false)
+			v.Offsets = append(v.Offsets, v.Keys.Len())
+			return nil
+		}
+	case *StructTreeReader:
+		if v, ok := v.(*StructVector); ok {
+			for i, name := range v.names {
+				child, ok := r.children[name]
+				if !ok {
+					return fmt.Errorf("no reader for struct field: %s", name)
+				}
+				if err := readVector(child, v.Fields[i]); err != nil {
+					return err
+				}
+			}
+			v.Nulls = append(v.Nulls, false)
+			return nil
+		}
+	case *convertTreeReader:
+		// Values converted to the type of the reader schema are appended
+		// as interface{} values.
+		return appendValue(v, r.Value())
+	case *UnionTreeReader:
+		if v, ok := v.(*UnionVector); ok {
+			tag := int(r.data.Byte())
+			if err := v.appendTag(tag); err != nil {
+				return err
+			}
+			child := r.children[tag]
+			if !child.Next() {
+				return nextErr(child)
+			}
+			return readVector(child, v.Children[tag])
+		}
+	default:
+		return fmt.Errorf("unsupported reader type: %T", r)
+	}
+	return fmt.Errorf("unexpected column vector %T for reader %T", v, r)
+}
+
+// nextErr returns the error that caused Next to return false for r.
+func nextErr(r TreeReader) error {
+	if err := r.Err(); err != nil && err != io.EOF {
+		return err
+	}
+	return fmt.Errorf("no value available from reader %T", r)
+}
+
+// appendValue appends a value returned by a TreeReader to v.
+func appendValue(v ColumnVector, value interface{}) error {
+	if value == nil {
+		v.appendNull()
+		return nil
+	}
+	switch v := v.(type) {
+	case *BooleanVector:
+		if b, ok := value.(bool); ok {
+			v.append(b)
+			return nil
+		}
+	case *LongVector:
+		switch i := value.(type) {
+		case int8:
+			v.append(int64(i))
+			return nil
+		case int64:
+			v.append(i)
+			return nil
+		case Date:
+			v.append(i.Unix() / 86400)
+			return nil
+		}
+	case *DoubleVector:
+		switch f := value.(type) {
+		case Float:
+			v.append(float64(f))
+			return nil
+		case Double:
+			v.append(float64(f))
+			return nil
+		}
+	case *BytesVector:
+		switch b := value.(type) {
+		case string:
+			v.append([]byte(b))
+			return nil
+		case []byte:
+			v.append(b)
+			return nil
+		}
+	case *DecimalVector:
+		if d, ok := value.(Decimal); ok {
+			v.append(d)
+			return nil
+		}
+	case *TimestampVector:
+		if t, ok := value.(time.Time); ok {
+			v.append(t)
+			return nil
+		}
+	case *ListVector:
+		if l, ok := value.([]interface{}); ok {
+			for _, element := range l {
+				if err := appendValue(v.Child, element); err != nil {
+					return err
+				}
+			}
+			v.Nulls = append(v.Nulls, false)
+			v.Offsets = append(v.Offsets, v.Child.Len())
+			return nil
+		}
+	case *MapVector:
+		if m, ok := value.([]MapEntry); ok {
+			for _, entry := range m {
+				if err := appendValue(v.Keys, entry.Key); err != nil {
+					return err
+				}
+				if err := appendValue(v.Values, entry.Value); err != nil {
+					return err
+				}
+			}
+			v.Nulls = append(v.Nulls, false)
+			v.Offsets = append(v.Offsets, v.Keys.Len())
+			return nil
+		}
+	case *StructVector:
+		if s, ok := value.(Struct); ok {
+			for i, name := range v.names {
+				if err := appendValue(v.Fields[i], s[name]); err != nil {
+					return err
+				}
+			}
+			v.Nulls = append(v.Nulls, false)
+			return nil
+		}
+	case *UnionVector:
+		if u, ok := value.(UnionValue); ok {
+			if err := v.appendTag(u.Tag); err != nil {
+				return err
+			}
+			return appendValue(v.Children[u.Tag], u.Value)
+		}
+	}
+	return fmt.Errorf("unexpected value %T for column vector %T", value, v)
+}
+
+// vectorValue returns the value at index i of the vector v of column td, as it
+// is returned by the Value method of the TreeReader of the column.
+func vectorValue(td *TypeDescription, v ColumnVector, i int) interface{} {
+	if v.IsNull(i) {
+		return nil
+	}
+	switch v := v.(type) {
+	case *BooleanVector:
+		return v.Values[i]
+	case *LongVector:
+		switch td.getCategory() {
+		case CategoryByte:
+			return int8(v.Values[i])
+		case CategoryDate:
+			return Date{time.Unix(86400*v.Values[i], 0).UTC()}
+		}
+		return v.Values[i]
+	case *DoubleVector:
+		if td.getCategory() == CategoryFloat {
+			return Float(v.Values[i])
+		}
+		return Double(v.Values[i])
+	case *BytesVector:
+		if td.getCategory() == CategoryBinary {
+			return append([]byte(nil), v.Bytes(i)...)
+		}
+		return string(v.Bytes(i))
+	case *DecimalVector:
+		return v.Values[i]
+	case *TimestampVector:
+		return v.Values[i]
+	case *ListVector:
+		l := make([]interface{}, v.Offsets[i+1]-v.Offsets[i])
+		for j := range l {
+			l[j] = vectorValue(td.children[0], v.Child, v.Offsets[i]+j)
+		}
+		return l
+	case *MapVector:
+		m := make([]MapEntry, v.Offsets[i+1]-v.Offsets[i])
+		for j := range m {
+			m[j] = MapEntry{
+				Key:   vectorValue(td.children[0], v.Keys, v.Offsets[i]+j),
+				Value: vectorValue(td.children[1], v.Values, v.Offsets[i]+j),
+			}
+		}
+		return m
+	case *StructVector:
+		st := make(Struct)
+		for j, name := range v.names {
+			st[name] = vectorValue(td.children[j], v.Fields[j], i)
+		}
+		return st
+	case *UnionVector:
+		tag := v.Tags[i]
+		return UnionValue{
+			Tag:   tag,
+			Value: vectorValue(td.children[tag], v.Children[tag], i),
+		}
+	}
+	return nil
+}
+
+// NextBatch reads up to b.Size rows of the current stripe into the column
+// vectors of b, replacing the rows it held previously, and returns true if any
+// rows were read. It returns false once the rows of the stripe are exhausted
+// or an error occurs. Values are read directly from the streams of the stripe
+// into the typed vectors of each column, without allocating an interface{}
+// for each value as Next does. Row is not updated by NextBatch.
+func (c *Cursor) NextBatch(b *Batch) bool {
+	if c.err != nil {
+		return false
+	}
+	if err := b.prepare(c.columns); err != nil {
+		c.err = err
+		return false
+	}
+	size := b.Size
+	if size <= 0 {
+		size = DefaultBatchSize
+	}
+	if c.decoded != nil {
+		return c.nextDecodedRows(b, size)
+	}
+	for b.Len < size {
+		if !c.next() {
+			break
+		}
+		for i, reader := range c.readers {
+			if err := readVector(reader, b.Columns[i]); err != nil {
+				c.err = err
+				return false
+			}
+		}
+		b.Len++
+	}
+	return b.Len > 0
+}
+
+// nextDecodedRows reads up to size rows decoded by the pipeline into the empty
+// Batch b. A batch decoded by the pipeline is swapped into b, rather than
+// copied, if none of its rows have been consumed and it fits within size.
+func (c *Cursor) nextDecodedRows(b *Batch, size int) bool {
+	for b.Len < size {
+		if c.batch == nil || c.batchRow+1 >= c.batch.Len {
+			if !c.nextDecodedBatch() {
+				break
+			}
+		}
+		if b.Len == 0 && c.batchRow == -1 && c.batch.Len <= size {
+			*b, *c.batch = *c.batch, *b
+			b.Size, c.batch.Size = c.batch.Size, b.Size
+			c.batchRow = c.batch.Len - 1
+			c.currentRow += b.Len
+			return true
+		}
+		c.batchRow++
+		for i, column := range c.columns {
+			value := vectorValue(column, c.batch.Columns[i], c.batchRow)
+			if err := appendValue(b.Columns[i], value); err != nil {
+				c.err = err
+				return false
+			}
+		}
+		c.currentRow++
+		b.Len++
+	}
+	return b.Len > 0
+}
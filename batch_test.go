@@ -0,0 +1,452 @@
+package orc
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/scritchley/orc/proto"
+)
+
+func TestCursorNextBatch(t *testing.T) {
+	examples := []string{
+		"TestOrcFile.test1.orc",
+		"TestOrcFile.testSeek.orc",
+		"TestOrcFile.testUnionAndTimestamp.orc",
+		"TestOrcFile.testDate1900.orc",
+		"decimal.orc",
+		"nulls-at-end-snappy.orc",
+	}
+
+	for _, example := range examples {
+		for _, concurrency := range []int{0, 2} {
+			r, err := Open("./examples/"+example, SetConcurrency(concurrency))
+			if err != nil {
+				t.Fatal(err)
+			}
+			cols := r.Schema().Columns()
+
+			// Read the same rows using Next, appending them to a Batch of the
+			// same length as each batch returned by NextBatch.
+			c := r.Select(cols...)
+			rows := r.Select(cols...)
+			b := NewBatch(100)
+			var expected Batch
+			var n int
+			for c.Stripes() {
+				if !rows.Stripes() {
+					t.Fatalf("Test failed, expected a stripe: %v", rows.Err())
+				}
+				for c.NextBatch(b) {
+					if b.Len > b.Size {
+						t.Fatalf("Test failed, expected at most %v rows got %v", b.Size, b.Len)
+					}
+					if err := expected.prepare(rows.columns); err != nil {
+						t.Fatal(err)
+					}
+					for i := 0; i < b.Len; i++ {
+						if !rows.Next() {
+							t.Fatalf("Test failed, expected row %v: %v", n+i, rows.Err())
+						}
+						for j, value := range rows.Row() {
+							if err := appendValue(expected.Columns[j], value); err != nil {
+								t.Fatal(err)
+							}
+						}
+					}
+					if !reflect.DeepEqual(b.Columns, expected.Columns) {
+						t.Fatalf("Test failed, expected batch of rows %v to %v of %s to match rows", n, n+b.Len, example)
+					}
+					n += b.Len
+				}
+			}
+			if err := c.Err(); err != nil {
+				t.Fatal(err)
+			}
+			if n != r.NumRows() {
+				t.Errorf("Test failed, expected %v rows got %v", r.NumRows(), n)
+			}
+			c.Close()
+			rows.Close()
+			r.Close()
+		}
+	}
+}
+
+func TestBatchVectors(t *testing.T) {
+	schema, err := ParseSchema("struct<int1:int,string1:string,list1:array<int>>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, SetSchema(schema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		if i%3 == 0 {
+			err = w.Write(nil, nil, nil)
+		} else {
+			err = w.Write(int64(i), fmt.Sprint(i), []int64{int64(i), int64(i * 10)})
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(&bytesSizedReaderAt{bytes.NewBuffer(buf.Bytes())})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := r.Select("int1", "string1", "list1")
+	if !c.Stripes() {
+		t.Fatalf("Test failed, expected a stripe: %v", c.Err())
+	}
+	b := NewBatch(4)
+	for batch := 0; c.NextBatch(b); batch++ {
+		ints := b.Columns[0].(*LongVector)
+		strs := b.Columns[1].(*BytesVector)
+		lists := b.Columns[2].(*ListVector)
+		elements := lists.Child.(*LongVector)
+		for j := 0; j < b.Len; j++ {
+			i := batch*b.Size + j
+			null := i%3 == 0
+			for _, column := range b.Columns {
+				if column.IsNull(j) != null {
+					t.Fatalf("Test failed, expected null to be %v for row %v", null, i)
+				}
+			}
+			if null {
+				if lists.Offsets[j] != lists.Offsets[j+1] {
+					t.Errorf("Test failed, expected no elements for null row %v", i)
+				}
+				continue
+			}
+			if ints.Values[j] != int64(i) {
+				t.Errorf("Test failed, expected %v got %v", i, ints.Values[j])
+			}
+			if s := string(strs.Bytes(j)); s != fmt.Sprint(i) {
+				t.Errorf("Test failed, expected %v got %v", i, s)
+			}
+			list := elements.Values[lists.Offsets[j]:lists.Offsets[j+1]]
+			if !reflect.DeepEqual(list, []int64{int64(i), int64(i * 10)}) {
+				t.Errorf("Test failed, expected %v got %v", []int64{int64(i), int64(i * 10)}, list)
+			}
+		}
+	}
+	if err := c.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWriterWriteBatch(t *testing.T) {
+	examples := []string{
+		"TestOrcFile.test1.orc",
+		"TestOrcFile.testSeek.orc",
+		"TestOrcFile.testUnionAndTimestamp.orc",
+		"TestOrcFile.testDate1900.orc",
+		"decimal.orc",
+		"nulls-at-end-snappy.orc",
+	}
+
+	// Decimals are rescaled to the scale of the column when written, so they
+	// are compared by value.
+	normalize := func(row []interface{}) []interface{} {
+		for i, value := range row {
+			if d, ok := value.(Decimal); ok {
+				row[i] = d.Rat().RatString()
+			}
+		}
+		return row
+	}
+
+	readRows := func(r *Reader) [][]interface{} {
+		var rows [][]interface{}
+		c := r.Select(r.Schema().Columns()...)
+		for c.Stripes() {
+			for c.Next() {
+				rows = append(rows, normalize(c.Row()))
+			}
+		}
+		if err := c.Err(); err != nil {
+			t.Fatal(err)
+		}
+		return rows
+	}
+
+	for _, example := range examples {
+		r, err := Open("./examples/" + example)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := readRows(r)
+
+		var buf bytes.Buffer
+		w, err := NewWriter(&buf, SetSchema(r.Schema()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.footer.RowIndexStride = ptrUint32(100)
+		c := r.Select(r.Schema().Columns()...)
+		b := NewBatch(256)
+		for c.Stripes() {
+			for c.NextBatch(b) {
+				if err := w.WriteBatch(b); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+		if err := c.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		r.Close()
+
+		written, err := NewReader(&bytesSizedReaderAt{bytes.NewBuffer(buf.Bytes())})
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows := readRows(written)
+		if !reflect.DeepEqual(rows, expected) {
+			t.Fatalf("Test failed, expected rows written by WriteBatch to match the rows of %s", example)
+		}
+
+		// The row index records the positions of each row group.
+		c = written.Select(written.Schema().Columns()...)
+		for _, n := range []int{len(rows) - 1, len(rows) / 2, 101, 0} {
+			if n >= len(rows) {
+				continue
+			}
+			if err := c.SeekRow(int64(n)); err != nil {
+				t.Fatal(err)
+			}
+			if !c.Next() {
+				t.Fatalf("Test failed, expected row %v: %v", n, c.Err())
+			}
+			if row := normalize(c.Row()); !reflect.DeepEqual(row, rows[n]) {
+				t.Errorf("Test failed, expected %v got %v", rows[n], row)
+			}
+		}
+	}
+}
+
+func TestWriterWriteBatchMatchesWrite(t *testing.T) {
+	schema, err := ParseSchema("struct<int1:int,byte1:tinyint,double1:double,float1:float,string1:string,date1:date,list1:array<bigint>>")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rows [][]interface{}
+	for i := 0; i < 1000; i++ {
+		if i%7 == 0 {
+			rows = append(rows, []interface{}{nil, nil, nil, nil, nil, nil, nil})
+			continue
+		}
+		rows = append(rows, []interface{}{
+			int64(i * 1000),
+			int8(i),
+			Double(float64(i) / 3),
+			Float(float32(i) / 7),
+			fmt.Sprint(i % 13),
+			Date{time.Unix(int64(i)*86400, 0).UTC()},
+			[]interface{}{int64(i), int64(-i)},
+		})
+	}
+
+	write := func(fn func(w *Writer) error) []byte {
+		var buf bytes.Buffer
+		w, err := NewWriter(&buf, SetSchema(schema), SetBloomFilterColumns([]string{"int1", "double1"}, 0.01))
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.footer.RowIndexStride = ptrUint32(100)
+		if err := fn(w); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+
+	expected := write(func(w *Writer) error {
+		for _, row := range rows {
+			values := make([]interface{}, len(row))
+			copy(values, row)
+			// Write accepts dates as a time.Time and bytes as a byte.
+			if d, ok := row[5].(Date); ok {
+				values[5] = d.Time
+			}
+			if b, ok := row[1].(int8); ok {
+				values[1] = byte(b)
+			}
+			if f, ok := row[3].(Float); ok {
+				values[3] = float32(f)
+			}
+			if err := w.Write(values...); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	got := write(func(w *Writer) error {
+		var b Batch
+		if err := b.prepare(schema.children); err != nil {
+			return err
+		}
+		for start := 0; start < len(rows); start += 300 {
+			for _, column := range b.Columns {
+				column.reset()
+			}
+			end := start + 300
+			if end > len(rows) {
+				end = len(rows)
+			}
+			for _, row := range rows[start:end] {
+				for i, value := range row {
+					if err := appendValue(b.Columns[i], value); err != nil {
+						return err
+					}
+				}
+			}
+			b.Len = end - start
+			if err := w.WriteBatch(&b); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if !bytes.Equal(got, expected) {
+		t.Errorf("Test failed, expected the file written by WriteBatch to match the file written by Write")
+	}
+}
+
+func benchmarkWriter(b *testing.B, fn func(w *Writer, values []int64) error) {
+	schema, err := ParseSchema("struct<int1:bigint,int2:bigint,int3:bigint,int4:bigint>")
+	if err != nil {
+		b.Fatal(err)
+	}
+	values := make([]int64, 1024)
+	for i := range values {
+		values[i] = int64(i) * 100003
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		w, err := NewWriter(ioutil.Discard, SetSchema(schema))
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := fn(w, values); err != nil {
+			b.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkWriterWrite(b *testing.B) {
+	benchmarkWriter(b, func(w *Writer, values []int64) error {
+		for _, value := range values {
+			if err := w.Write(value, value, value, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func BenchmarkWriterWriteBatch(b *testing.B) {
+	benchmarkWriter(b, func(w *Writer, values []int64) error {
+		column := &LongVector{Values: values}
+		return w.WriteBatch(&Batch{
+			Len:     len(values),
+			Columns: []ColumnVector{column, column, column, column},
+		})
+	})
+}
+
+func TestWriterWriteBatchByteRange(t *testing.T) {
+	for _, value := range []int64{128, 255, -129} {
+		bw, err := NewByteTreeWriter(CategoryByte, CompressionNone{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		v := &LongVector{}
+		v.append(127)
+		v.append(value)
+		if bw.writeVector(v, 0, 1) != nil {
+			t.Errorf("Test failed, expected no error writing 127")
+		}
+		if bw.writeVector(v, 1, 2) == nil {
+			t.Errorf("Test failed, expected an error writing out of range value %v", value)
+		}
+	}
+}
+
+func TestMapTreeReaderTruncated(t *testing.T) {
+	encoding := &proto.ColumnEncoding{Kind: proto.ColumnEncoding_DIRECT.Enum()}
+	intStream := func(signed bool, values ...int64) *bytes.Buffer {
+		var buf bytes.Buffer
+		w := NewRunLengthIntegerWriter(&buf, signed)
+		for _, v := range values {
+			if err := w.WriteInt(v); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return &buf
+	}
+	newReader := func() *MapTreeReader {
+		key, err := NewIntegerTreeReader(nil, intStream(true, 1, 2, 3, 4), encoding)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The value stream is missing the value of the last entry.
+		value, err := NewIntegerTreeReader(nil, intStream(true, 10, 20, 30), encoding)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m, err := NewMapTreeReader(nil, intStream(false, 2, 2), key, value, encoding)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+
+	m := newReader()
+	var rows int
+	for m.Next() {
+		m.Value()
+		rows++
+	}
+	if rows != 2 {
+		t.Errorf("Expected 2 rows, got %v", rows)
+	}
+	if m.Err() == nil {
+		t.Errorf("Expected an error reading a truncated value stream")
+	}
+
+	m = newReader()
+	v := &MapVector{Keys: &LongVector{}, Values: &LongVector{}}
+	for m.Next() {
+		if err := readVector(m, v); err != nil {
+			break
+		}
+	}
+	if m.Err() == nil {
+		t.Errorf("Expected an error reading a truncated value stream into a vector")
+	}
+}
@@ -0,0 +1,371 @@
+package orc
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+	"strings"
+	"time"
+
+	"github.com/scritchley/orc/proto"
+)
+
+var (
+	errCorruptBloomFilter = errors.New("corrupt bloom filter")
+)
+
+const (
+	// DefaultBloomFilterFpp is the default false positive probability of bloom filters.
+	DefaultBloomFilterFpp = 0.05
+	// bloomFilterEncodingUTF8 is the ColumnEncoding bloomEncoding value for bloom
+	// filters written to BLOOM_FILTER_UTF8 streams.
+	bloomFilterEncodingUTF8 uint32 = 1
+	// writerVersionORC101 is the first writer version to hash strings added to
+	// bloom filters as UTF-8 rather than the writer's default charset.
+	writerVersionORC101 uint32 = 5
+	// writerVersionORC135 is the first writer version to add timestamps to bloom
+	// filters in UTC.
+	writerVersionORC135 uint32 = 6
+)
+
+// BloomFilter is a bloom filter that is compatible with the bloom filters
+// defined by the ORC specification. String, binary and decimal values are
+// hashed using Murmur3 whilst all other values are hashed as 64 bit integers.
+type BloomFilter struct {
+	category         Category
+	numBits          int32
+	numHashFunctions int32
+	bitSet           []uint64
+	// murmur3Integers is set for bloom filters written by early versions of Hive,
+	// which hash integer values using Murmur3 rather than an integer hash.
+	murmur3Integers bool
+	// ignoreTimestamps is set for bloom filters written before timestamps were
+	// added in UTC, which cannot be tested reliably.
+	ignoreTimestamps bool
+	// ignoreStrings is set for string bloom filters written before strings were
+	// hashed as UTF-8, which cannot be tested reliably.
+	ignoreStrings bool
+}
+
+// NewBloomFilter returns a new BloomFilter sized to hold expectedEntries values
+// with a false positive probability of fpp.
+func NewBloomFilter(expectedEntries int64, fpp float64) *BloomFilter {
+	if expectedEntries < 1 {
+		expectedEntries = 1
+	}
+	n := float64(expectedEntries)
+	nb := int32(-n * math.Log(fpp) / (math.Ln2 * math.Ln2))
+	// Round the number of bits up to a multiple of 64.
+	numBits := nb + (64 - nb%64)
+	numHashFunctions := int32(math.Round(float64(numBits) / n * math.Ln2))
+	if numHashFunctions < 1 {
+		numHashFunctions = 1
+	}
+	return &BloomFilter{
+		numBits:          numBits,
+		numHashFunctions: numHashFunctions,
+		bitSet:           make([]uint64, numBits/64),
+	}
+}
+
+// AddBytes adds the byte slice to the bloom filter.
+func (b *BloomFilter) AddBytes(byt []byte) {
+	b.addHash(murmur3Hash64(byt))
+}
+
+// AddString adds the UTF-8 bytes of the string to the bloom filter.
+func (b *BloomFilter) AddString(s string) {
+	b.AddBytes([]byte(s))
+}
+
+// AddInt adds the integer to the bloom filter.
+func (b *BloomFilter) AddInt(i int64) {
+	b.addHash(b.intHash(i))
+}
+
+// AddFloat adds the floating point number to the bloom filter.
+func (b *BloomFilter) AddFloat(f float64) {
+	b.addHash(b.floatHash(f))
+}
+
+// MightContain returns false if the value is definitely not contained in the
+// bloom filter and true if it might be. The value should be of the type written
+// to the column, float values are compared as float64 values and values that
+// cannot be tested always return true.
+func (b *BloomFilter) MightContain(value interface{}) bool {
+	hash, ok := b.hash(b.category, value)
+	if !ok {
+		return true
+	}
+	return b.testHash(hash)
+}
+
+// add adds the value i, written to a column of the provided category, to the
+// bloom filter. Nil values and values of unsupported types are ignored.
+func (b *BloomFilter) add(category Category, i interface{}) {
+	if hash, ok := b.hash(category, i); ok {
+		b.addHash(hash)
+	}
+}
+
+// hash returns the hash of the value i for a column of the provided category,
+// or false if the value cannot be hashed.
+func (b *BloomFilter) hash(category Category, i interface{}) (uint64, bool) {
+	switch t := i.(type) {
+	case bool:
+		if t {
+			return b.intHash(1), true
+		}
+		return b.intHash(0), true
+	case int:
+		return b.intHash(int64(t)), true
+	case int8:
+		return b.intHash(int64(t)), true
+	case int16:
+		return b.intHash(int64(t)), true
+	case int32:
+		return b.intHash(int64(t)), true
+	case int64:
+		return b.intHash(t), true
+	case float32:
+		return b.floatHash(float64(t)), true
+	case Float:
+		return b.floatHash(float64(t)), true
+	case float64:
+		return b.floatHash(t), true
+	case Double:
+		return b.floatHash(float64(t)), true
+	case string:
+		if b.ignoreStrings {
+			return 0, false
+		}
+		return murmur3Hash64([]byte(t)), true
+	case []byte:
+		if b.ignoreStrings {
+			return 0, false
+		}
+		return murmur3Hash64(t), true
+	case Decimal:
+		return murmur3Hash64([]byte(bloomFilterDecimalString(t))), true
+	case time.Time:
+		if category == CategoryDate {
+			return b.intHash(daysSinceEpoch(t)), true
+		}
+		if b.ignoreTimestamps {
+			return 0, false
+		}
+		// Timestamps are added as milliseconds since the epoch in UTC.
+		return b.intHash(timestampMillis(t)), true
+	default:
+		return 0, false
+	}
+}
+
+func (b *BloomFilter) intHash(i int64) uint64 {
+	if b.murmur3Integers {
+		var byt [8]byte
+		binary.LittleEndian.PutUint64(byt[:], uint64(i))
+		return murmur3Hash64(byt[:])
+	}
+	return uint64(integerHash64(i))
+}
+
+func (b *BloomFilter) floatHash(f float64) uint64 {
+	return b.intHash(int64(math.Float64bits(f)))
+}
+
+func (b *BloomFilter) addHash(hash uint64) {
+	hash1 := int32(hash)
+	hash2 := int32(hash >> 32)
+	for i := int32(1); i <= b.numHashFunctions; i++ {
+		combinedHash := hash1 + i*hash2
+		// Flip all the bits if it's negative to guarantee a positive number.
+		if combinedHash < 0 {
+			combinedHash = ^combinedHash
+		}
+		pos := combinedHash % b.numBits
+		b.bitSet[pos>>6] |= 1 << uint(pos&63)
+	}
+}
+
+func (b *BloomFilter) testHash(hash uint64) bool {
+	hash1 := int32(hash)
+	hash2 := int32(hash >> 32)
+	for i := int32(1); i <= b.numHashFunctions; i++ {
+		combinedHash := hash1 + i*hash2
+		if combinedHash < 0 {
+			combinedHash = ^combinedHash
+		}
+		pos := combinedHash % b.numBits
+		if b.bitSet[pos>>6]&(1<<uint(pos&63)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Proto returns the bloom filter as a proto.BloomFilter using the UTF-8 bitset encoding.
+func (b *BloomFilter) Proto() *proto.BloomFilter {
+	byt := make([]byte, len(b.bitSet)*8)
+	for i, word := range b.bitSet {
+		binary.LittleEndian.PutUint64(byt[i*8:], word)
+	}
+	return &proto.BloomFilter{
+		NumHashFunctions: ptrUint32(uint32(b.numHashFunctions)),
+		Utf8Bitset:       byt,
+	}
+}
+
+// newBloomFilterFromProto returns the BloomFilter decoded from the proto.BloomFilter
+// of a column of the provided category, written by a writer of writerVersion.
+func newBloomFilterFromProto(category Category, p *proto.BloomFilter, writerVersion uint32) (*BloomFilter, error) {
+	b := &BloomFilter{
+		category:         category,
+		numHashFunctions: int32(p.GetNumHashFunctions()),
+		ignoreTimestamps: writerVersion < writerVersionORC135,
+	}
+	switch category {
+	case CategoryString, CategoryChar, CategoryVarchar:
+		b.ignoreStrings = writerVersion < writerVersionORC101
+	}
+	switch {
+	case len(p.Utf8Bitset) > 0:
+		if len(p.Utf8Bitset)%8 != 0 {
+			return nil, errCorruptBloomFilter
+		}
+		b.bitSet = make([]uint64, len(p.Utf8Bitset)/8)
+		for i := range b.bitSet {
+			b.bitSet[i] = binary.LittleEndian.Uint64(p.Utf8Bitset[i*8:])
+		}
+	case len(p.XXX_unrecognized) > 0:
+		// Early versions of Hive wrote the expected entries and false positive
+		// probability, in place of the number of hash functions and bitset,
+		// followed by the bitset as varints in field 3.
+		expectedEntries := int64(p.GetNumHashFunctions())
+		if len(p.Bitset) != 1 || expectedEntries == 0 {
+			return nil, errCorruptBloomFilter
+		}
+		fpp := math.Float64frombits(p.Bitset[0])
+		bitSet, err := decodeVarintField(p.XXX_unrecognized, 3)
+		if err != nil {
+			return nil, err
+		}
+		n := float64(expectedEntries)
+		numBits := int32(-n * math.Log(fpp) / (math.Ln2 * math.Ln2))
+		if numBits <= 0 || int(numBits) > len(bitSet)*64 {
+			return nil, errCorruptBloomFilter
+		}
+		b.bitSet = bitSet
+		b.numBits = numBits
+		b.numHashFunctions = int32(math.Round(float64(numBits) / n * math.Ln2))
+		if b.numHashFunctions < 1 {
+			b.numHashFunctions = 1
+		}
+		b.murmur3Integers = true
+		return b, nil
+	default:
+		b.bitSet = p.Bitset
+	}
+	b.numBits = int32(len(b.bitSet) * 64)
+	if b.numBits == 0 {
+		return nil, errCorruptBloomFilter
+	}
+	return b, nil
+}
+
+// decodeVarintField decodes the values of the repeated varint field with the
+// provided number from the encoded protobuf fields in byt.
+func decodeVarintField(byt []byte, field uint64) ([]uint64, error) {
+	var values []uint64
+	for len(byt) > 0 {
+		tag, n := binary.Uvarint(byt)
+		if n <= 0 {
+			return nil, errCorruptBloomFilter
+		}
+		byt = byt[n:]
+		// Only varint fields are expected.
+		if tag&7 != 0 {
+			return nil, errCorruptBloomFilter
+		}
+		value, n := binary.Uvarint(byt)
+		if n <= 0 {
+			return nil, errCorruptBloomFilter
+		}
+		byt = byt[n:]
+		if tag>>3 == field {
+			values = append(values, value)
+		}
+	}
+	return values, nil
+}
+
+// bloomFilterDecimalString returns the string form of the decimal that is added
+// to bloom filters, which has any trailing fractional zeros removed.
+func bloomFilterDecimalString(d Decimal) string {
+	s := d.String()
+	if strings.IndexByte(s, '.') == -1 {
+		return s
+	}
+	s = strings.TrimRight(s, "0")
+	s = strings.TrimSuffix(s, ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+const (
+	murmur3C1   uint64 = 0x87c37b91114253d5
+	murmur3C2   uint64 = 0x4cf5ad432745937f
+	murmur3R1          = 31
+	murmur3R2          = 27
+	murmur3M    uint64 = 5
+	murmur3N1   uint64 = 0x52dce729
+	murmur3Seed uint64 = 104729
+)
+
+// murmur3Hash64 returns the 64 bit Murmur3 hash of data as used by ORC bloom filters.
+func murmur3Hash64(data []byte) uint64 {
+	hash := murmur3Seed
+	nblocks := len(data) >> 3
+	for i := 0; i < nblocks; i++ {
+		k := binary.LittleEndian.Uint64(data[i<<3:])
+		k *= murmur3C1
+		k = bits.RotateLeft64(k, murmur3R1)
+		k *= murmur3C2
+		hash ^= k
+		hash = bits.RotateLeft64(hash, murmur3R2)*murmur3M + murmur3N1
+	}
+	tail := data[nblocks<<3:]
+	if len(tail) > 0 {
+		var k uint64
+		for i := len(tail) - 1; i >= 0; i-- {
+			k = k<<8 | uint64(tail[i])
+		}
+		k *= murmur3C1
+		k = bits.RotateLeft64(k, murmur3R1)
+		k *= murmur3C2
+		hash ^= k
+	}
+	hash ^= uint64(len(data))
+	hash ^= hash >> 33
+	hash *= 0xff51afd7ed558ccd
+	hash ^= hash >> 33
+	hash *= 0xc4ceb9fe1a85ec53
+	hash ^= hash >> 33
+	return hash
+}
+
+// integerHash64 returns Thomas Wang's 64 bit integer hash of key as used by ORC
+// bloom filters.
+func integerHash64(key int64) int64 {
+	key = (^key) + (key << 21)
+	key = key ^ (key >> 24)
+	key = (key + (key << 3)) + (key << 8)
+	key = key ^ (key >> 14)
+	key = (key + (key << 2)) + (key << 4)
+	key = key ^ (key >> 28)
+	key = key + (key << 31)
+	return key
+}
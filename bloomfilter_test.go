@@ -0,0 +1,187 @@
+package orc
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestBloomFilter(t *testing.T) {
+	n := 10000
+	fpp := 0.05
+	b := NewBloomFilter(int64(n), fpp)
+	s := NewBloomFilter(int64(n), fpp)
+
+	if b.numBits%64 != 0 {
+		t.Errorf("Expected the number of bits to be a multiple of 64, got %v", b.numBits)
+	}
+
+	for i := 0; i < n; i++ {
+		b.AddInt(int64(i))
+		s.AddString(fmt.Sprintf("value-%d", i))
+	}
+
+	for i := 0; i < n; i++ {
+		if !b.testHash(uint64(integerHash64(int64(i)))) {
+			t.Errorf("Expected bloom filter to contain %v", i)
+		}
+		if !s.testHash(murmur3Hash64([]byte(fmt.Sprintf("value-%d", i)))) {
+			t.Errorf("Expected bloom filter to contain value-%d", i)
+		}
+	}
+
+	var falsePositives int
+	for i := n; i < 2*n; i++ {
+		if b.testHash(uint64(integerHash64(int64(i)))) {
+			falsePositives++
+		}
+	}
+	if rate := float64(falsePositives) / float64(n); rate > 2*fpp {
+		t.Errorf("Expected a false positive rate below %v, got %v", 2*fpp, rate)
+	}
+}
+
+func TestBloomFilterDecimalString(t *testing.T) {
+	testCases := []struct {
+		value    Decimal
+		expected string
+	}{
+		{NewDecimal(big.NewInt(12300), 3), "12.3"},
+		{NewDecimal(big.NewInt(1200), 2), "12"},
+		{NewDecimal(big.NewInt(-500), 2), "-5"},
+		{NewDecimal(big.NewInt(0), 2), "0"},
+		{NewDecimal(big.NewInt(15), 0), "15"},
+	}
+
+	for _, tc := range testCases {
+		if got := bloomFilterDecimalString(tc.value); got != tc.expected {
+			t.Errorf("Expected %s got %s", tc.expected, got)
+		}
+	}
+}
+
+func TestBloomFilterLegacy(t *testing.T) {
+	r, err := Open("./examples/over1k_bloom.orc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	columns := []string{"_col0", "_col2", "_col3", "_col5", "_col7", "_col8", "_col10"}
+	c := r.Select(columns...)
+	for c.Stripes() {
+		filters := make([][]*BloomFilter, len(columns))
+		for i, column := range columns {
+			filters[i], err = c.BloomFilter(column)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		row := 0
+		stride := int(r.footer.GetRowIndexStride())
+		for c.Next() {
+			for i, value := range c.Row() {
+				if value == nil {
+					continue
+				}
+				if !filters[i][row/stride].MightContain(value) {
+					t.Errorf("Expected bloom filter for column %s to contain %v", columns[i], value)
+				}
+			}
+			row++
+		}
+		// Strings in bloom filters written before ORC-101 were not hashed as
+		// UTF-8 so the string column _col7 must not exclude any values.
+		for _, filter := range filters[4] {
+			if !filter.MightContain("not-a-value") {
+				t.Errorf("Expected legacy string bloom filter to contain every value")
+			}
+		}
+	}
+	if err := c.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBloomFilterRoundtrip(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	schema, err := ParseSchema("struct<int1:int,double1:double,string1:string,decimal1:decimal(10,2),date1:date,timestamp1:timestamp>")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	columns := []string{"int1", "double1", "string1", "decimal1", "date1", "timestamp1"}
+	w, err := NewWriter(buf, SetSchema(schema), SetBloomFilterColumns(columns, DefaultBloomFilterFpp))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts := time.Date(2017, 3, 4, 5, 6, 7, 8000000, time.UTC)
+	length := 1000
+	for i := 0; i < length; i++ {
+		err = w.Write(int64(i), float64(i)/10, fmt.Sprintf("string-%d", i), NewDecimal(big.NewInt(int64(i)*10), 2), ts.AddDate(0, 0, i), ts.Add(time.Duration(i)*time.Second))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(&bytesSizedReaderAt{bytes.NewBuffer(buf.Bytes())})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := r.Select(columns...)
+	if !c.Stripes() {
+		t.Fatal(c.Err())
+	}
+
+	filters := make(map[string]*BloomFilter)
+	for _, column := range columns {
+		f, err := c.BloomFilter(column)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(f) == 0 {
+			t.Fatalf("Expected bloom filters for column %s", column)
+		}
+		filters[column] = f[0]
+	}
+
+	for i := 0; i < length; i++ {
+		values := map[string]interface{}{
+			"int1":       i,
+			"double1":    float64(i) / 10,
+			"string1":    fmt.Sprintf("string-%d", i),
+			"decimal1":   NewDecimal(big.NewInt(int64(i)), 1),
+			"date1":      ts.AddDate(0, 0, i),
+			"timestamp1": ts.Add(time.Duration(i) * time.Second),
+		}
+		for column, value := range values {
+			if !filters[column].MightContain(value) {
+				t.Errorf("Expected bloom filter for column %s to contain %v", column, value)
+			}
+		}
+	}
+
+	var found int
+	for i := length; i < 2*length; i++ {
+		if filters["string1"].MightContain(fmt.Sprintf("string-%d", i)) {
+			found++
+		}
+	}
+	if found == length {
+		t.Errorf("Expected bloom filter to exclude values that were not written")
+	}
+
+	if _, err := c.BloomFilter("missing"); err == nil {
+		t.Errorf("Expected an error for a missing column")
+	}
+}
@@ -1,6 +1,9 @@
 package orc
 
-import "io"
+import (
+	"fmt"
+	"io"
+)
 
 type BooleanReader struct {
 	*RunLengthByteReader
@@ -33,6 +36,32 @@ func (b *BooleanReader) Next() bool {
 	return true
 }
 
+// seek moves the reader to the byte provided by p, then skips the number of
+// bits that follows it.
+func (b *BooleanReader) seek(p *positionProvider) error {
+	if err := b.RunLengthByteReader.seek(p); err != nil {
+		return err
+	}
+	b.bitsInData = 0
+	b.data = 0
+	b.err = nil
+	skip, err := p.next()
+	if err != nil {
+		return err
+	}
+	if skip > 8 {
+		return fmt.Errorf("invalid number of bits to skip: %v", skip)
+	}
+	if skip > 0 {
+		if !b.RunLengthByteReader.Next() {
+			return b.RunLengthByteReader.Err()
+		}
+		b.data = b.RunLengthByteReader.Byte() << skip
+		b.bitsInData = 8 - int(skip)
+	}
+	return nil
+}
+
 func (b *BooleanReader) Bool() bool {
 	return b.val
 }
@@ -45,6 +45,12 @@ func (b *BooleanWriter) flushBools() error {
 	return nil
 }
 
+// Positions returns the position of the underlying byte run length encoded
+// stream followed by the number of bits buffered for the next byte.
+func (b *BooleanWriter) Positions() []uint64 {
+	return append(b.RunLengthByteWriter.Positions(), uint64(b.bitsInData))
+}
+
 func (b *BooleanWriter) Flush() error {
 	err := b.flushBools()
 	if err != nil {
@@ -12,7 +12,6 @@ type BufferedWriter struct {
 	encodedBuffer      *bytes.Buffer
 	codec              CompressionCodec
 	chunkSize          int
-	checkpoint         uint64
 	written            uint64
 	sync.Mutex
 }
@@ -24,7 +23,7 @@ func NewBufferedWriter(codec CompressionCodec) *BufferedWriter {
 	switch codec.(type) {
 	case CompressionNone:
 		chunkSize = 1
-	case CompressionZlib:
+	case CompressionZlib, CompressionSnappy, CompressionLZ4, CompressionZstd:
 		chunkSize = int(DefaultCompressionChunkSize)
 	}
 	return &BufferedWriter{
@@ -118,23 +117,19 @@ func (b *BufferedWriter) spill() error {
 	return nil
 }
 
+// Positions returns the current position of the stream. Uncompressed streams
+// record the number of bytes written, whilst compressed streams record the
+// offset of the current chunk followed by the number of uncompressed bytes
+// buffered within it.
 func (b *BufferedWriter) Positions() []uint64 {
 	b.Lock()
 	defer b.Unlock()
 
-	//TODO: Do we still need the checkpoint?
 	switch b.codec.(type) {
 	case CompressionNone:
-		checkpoint := b.checkpoint
-		b.checkpoint = b.written
-		return []uint64{checkpoint}
+		return []uint64{uint64(b.encodedBuffer.Len() + b.uncompressedBuffer.Len())}
 	default:
-		//TODO: check if this is correct
-		checkpoint := b.checkpoint
-		b.checkpoint = b.written
-		return []uint64{checkpoint}
-
-		// return nil
+		return []uint64{uint64(b.encodedBuffer.Len()), uint64(b.uncompressedBuffer.Len())}
 	}
 }
 
@@ -164,10 +159,12 @@ func (b *BufferedWriter) Close() error {
 	return b.spill()
 }
 
-// Reset resets the underlying encoded buffer
+// Reset discards any buffered and encoded bytes.
 func (b *BufferedWriter) Reset() {
 	b.Lock()
 	defer b.Unlock()
 
+	b.uncompressedBuffer.Reset()
 	b.encodedBuffer.Reset()
+	b.written = 0
 }
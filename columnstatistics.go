@@ -1,6 +1,8 @@
 package orc
 
 import (
+	"math"
+	"math/big"
 	"time"
 
 	"github.com/scritchley/orc/proto"
@@ -10,14 +12,22 @@ type statisticsMap map[int]ColumnStatistics
 
 func NewColumnStatistics(category Category) ColumnStatistics {
 	switch category {
-	case CategoryInt, CategoryShort, CategoryLong:
+	case CategoryByte, CategoryInt, CategoryShort, CategoryLong:
 		return NewIntegerStatistics()
-	case CategoryString:
+	case CategoryFloat, CategoryDouble:
+		return NewDoubleStatistics()
+	case CategoryDate:
+		return NewDateStatistics()
+	case CategoryString, CategoryVarchar, CategoryChar:
 		return NewStringStatistics()
 	case CategoryBoolean:
 		return NewBucketStatistics()
-	case CategoryTimestamp:
+	case CategoryTimestamp, CategoryTimestampInstant:
 		return NewTimestampStatistics()
+	case CategoryBinary:
+		return NewBinaryStatistics()
+	case CategoryDecimal:
+		return NewDecimalStatistics()
 	default:
 		return NewBaseStatistics()
 	}
@@ -69,6 +79,66 @@ type ColumnStatistics interface {
 	Reset()
 }
 
+// intAdder is implemented by ColumnStatistics that can add an integer value
+// without it being converted to an interface{}.
+type intAdder interface {
+	addInt(value int64)
+}
+
+// intsAdder is implemented by ColumnStatistics that can add a slice of integer
+// values at once.
+type intsAdder interface {
+	addInts(values []int64)
+}
+
+// doubleAdder is implemented by ColumnStatistics that can add a floating point
+// value without it being converted to an interface{}.
+type doubleAdder interface {
+	addDouble(value float64)
+}
+
+// valueCounter is implemented by ColumnStatistics that can count a value that
+// is not null without it being provided.
+type valueCounter interface {
+	addValue()
+}
+
+// countValue counts a value that is not null in the statistics s.
+func countValue(s ColumnStatistics) {
+	if c, ok := s.(valueCounter); ok {
+		c.addValue()
+	}
+}
+
+// addInt adds the integer value to the statistics s.
+func addInt(s ColumnStatistics, value int64) {
+	if a, ok := s.(intAdder); ok {
+		a.addInt(value)
+		return
+	}
+	s.Add(value)
+}
+
+// addInts adds the integer values to the statistics s.
+func addInts(s ColumnStatistics, values []int64) {
+	if a, ok := s.(intsAdder); ok {
+		a.addInts(values)
+		return
+	}
+	for _, value := range values {
+		addInt(s, value)
+	}
+}
+
+// addDouble adds the floating point value to the statistics s.
+func addDouble(s ColumnStatistics, value float64) {
+	if a, ok := s.(doubleAdder); ok {
+		a.addDouble(value)
+		return
+	}
+	s.Add(value)
+}
+
 type BaseStatistics struct {
 	*proto.ColumnStatistics
 }
@@ -84,21 +154,59 @@ func NewBaseStatistics() BaseStatistics {
 	}
 }
 
+// Add records a null value if value is nil, otherwise it counts the value, as
+// the number of values does not include nulls.
 func (b BaseStatistics) Add(value interface{}) {
-	if hasNull := value == nil; hasNull {
-		*b.HasNull = hasNull
+	if value == nil {
+		*b.HasNull = true
+		return
 	}
 	n := b.ColumnStatistics.GetNumberOfValues() + 1
 	*b.ColumnStatistics.NumberOfValues = n
 }
 
+// addValue counts a value that is not null.
+func (b BaseStatistics) addValue() {
+	n := b.ColumnStatistics.GetNumberOfValues() + 1
+	*b.ColumnStatistics.NumberOfValues = n
+}
+
+// addValues counts n values that are not null.
+func (b BaseStatistics) addValues(n int) {
+	*b.ColumnStatistics.NumberOfValues = b.ColumnStatistics.GetNumberOfValues() + uint64(n)
+}
+
+// addInt counts an integer value for columns whose statistics do not depend
+// on their values.
+func (b BaseStatistics) addInt(value int64) {
+	b.addValue()
+}
+
+// addDouble counts a floating point value for columns whose statistics do not
+// depend on their values.
+func (b BaseStatistics) addDouble(value float64) {
+	b.addValue()
+}
+
 func (b BaseStatistics) Merge(other ColumnStatistics) {
 	if bs, ok := other.(BaseStatistics); ok {
 		numValues := b.GetNumberOfValues() + bs.GetNumberOfValues()
 		*b.NumberOfValues = numValues
+		if bs.GetHasNull() {
+			*b.HasNull = true
+		}
 	}
 }
 
+// Reset resets the number of values and whether there are nulls, leaving the
+// statistics of any type set on the underlying proto.ColumnStatistics.
+func (b BaseStatistics) Reset() {
+	var hasNull bool
+	var numValues uint64
+	b.NumberOfValues = &numValues
+	b.HasNull = &hasNull
+}
+
 func (b BaseStatistics) Statistics() *proto.ColumnStatistics {
 	return b.ColumnStatistics
 }
@@ -110,11 +218,9 @@ type IntegerStatistics struct {
 
 func (i *IntegerStatistics) Merge(other ColumnStatistics) {
 	if is, ok := other.(*IntegerStatistics); ok {
-		if is.IntStatistics.GetMaximum() > i.IntStatistics.GetMaximum() {
-			i.IntStatistics.Maximum = is.IntStatistics.Maximum
-		}
-		if is.IntStatistics.GetMinimum() < i.IntStatistics.GetMinimum() {
-			i.IntStatistics.Minimum = is.IntStatistics.Minimum
+		if is.minSet {
+			i.addMinMax(is.IntStatistics.GetMinimum())
+			i.addMinMax(is.IntStatistics.GetMaximum())
 		}
 		sum := i.IntStatistics.GetSum() + is.IntStatistics.GetSum()
 		*i.IntStatistics.Sum = sum
@@ -124,25 +230,57 @@ func (i *IntegerStatistics) Merge(other ColumnStatistics) {
 
 func (i *IntegerStatistics) Add(value interface{}) {
 	if val, ok := value.(int64); ok {
-		if i.IntStatistics.Maximum == nil {
-			valCopy := val
-			i.IntStatistics.Maximum = &valCopy
-		} else if val > i.IntStatistics.GetMaximum() {
-			*i.IntStatistics.Maximum = val
-		}
-		if !i.minSet {
-			valCopy := val
-			i.IntStatistics.Minimum = &valCopy
-			i.minSet = true
-		} else if val < i.IntStatistics.GetMinimum() {
-			*i.IntStatistics.Minimum = val
-		}
-		sum := i.IntStatistics.GetSum() + val
-		*i.IntStatistics.Sum = sum
+		i.addInt(val)
+		return
 	}
 	i.BaseStatistics.Add(value)
 }
 
+func (i *IntegerStatistics) addInt(val int64) {
+	i.addMinMax(val)
+	sum := i.IntStatistics.GetSum() + val
+	*i.IntStatistics.Sum = sum
+	i.BaseStatistics.addValue()
+}
+
+// addInts adds the minimum, maximum and sum of the values to the statistics.
+func (i *IntegerStatistics) addInts(values []int64) {
+	if len(values) == 0 {
+		return
+	}
+	min, max := values[0], values[0]
+	sum := i.IntStatistics.GetSum()
+	for _, val := range values {
+		if val < min {
+			min = val
+		} else if val > max {
+			max = val
+		}
+		sum += val
+	}
+	i.addMinMax(min)
+	i.addMinMax(max)
+	*i.IntStatistics.Sum = sum
+	i.BaseStatistics.addValues(len(values))
+}
+
+// addMinMax updates the minimum and maximum with the value.
+func (i *IntegerStatistics) addMinMax(val int64) {
+	if i.IntStatistics.Maximum == nil {
+		valCopy := val
+		i.IntStatistics.Maximum = &valCopy
+	} else if val > i.IntStatistics.GetMaximum() {
+		*i.IntStatistics.Maximum = val
+	}
+	if !i.minSet {
+		valCopy := val
+		i.IntStatistics.Minimum = &valCopy
+		i.minSet = true
+	} else if val < i.IntStatistics.GetMinimum() {
+		*i.IntStatistics.Minimum = val
+	}
+}
+
 func (i *IntegerStatistics) Statistics() *proto.ColumnStatistics {
 	return i.ColumnStatistics
 }
@@ -180,11 +318,9 @@ func NewStringStatistics() *StringStatistics {
 
 func (s *StringStatistics) Merge(other ColumnStatistics) {
 	if ss, ok := other.(*StringStatistics); ok {
-		if ss.StringStatistics.GetMaximum() > s.StringStatistics.GetMaximum() {
-			s.StringStatistics.Maximum = ss.StringStatistics.Maximum
-		}
-		if ss.StringStatistics.GetMinimum() < s.StringStatistics.GetMinimum() {
-			s.StringStatistics.Minimum = ss.StringStatistics.Minimum
+		if ss.minSet {
+			s.addMinMax(ss.StringStatistics.GetMinimum())
+			s.addMinMax(ss.StringStatistics.GetMaximum())
 		}
 		sum := s.StringStatistics.GetSum() + ss.StringStatistics.GetSum()
 		*s.StringStatistics.Sum = sum
@@ -194,25 +330,30 @@ func (s *StringStatistics) Merge(other ColumnStatistics) {
 
 func (s *StringStatistics) Add(value interface{}) {
 	if val, ok := value.(string); ok {
-		if s.StringStatistics.Maximum == nil {
-			valCopy := val
-			s.StringStatistics.Maximum = &valCopy
-		} else if val > s.StringStatistics.GetMaximum() {
-			*s.StringStatistics.Maximum = val
-		}
-		if !s.minSet {
-			valCopy := val
-			s.StringStatistics.Minimum = &valCopy
-			s.minSet = true
-		} else if val < s.StringStatistics.GetMinimum() {
-			*s.StringStatistics.Minimum = val
-		}
+		s.addMinMax(val)
 		sum := s.StringStatistics.GetSum() + int64(len(val))
 		*s.StringStatistics.Sum = sum
 	}
 	s.BaseStatistics.Add(value)
 }
 
+// addMinMax updates the minimum and maximum with the value.
+func (s *StringStatistics) addMinMax(val string) {
+	if s.StringStatistics.Maximum == nil {
+		valCopy := val
+		s.StringStatistics.Maximum = &valCopy
+	} else if val > s.StringStatistics.GetMaximum() {
+		*s.StringStatistics.Maximum = val
+	}
+	if !s.minSet {
+		valCopy := val
+		s.StringStatistics.Minimum = &valCopy
+		s.minSet = true
+	} else if val < s.StringStatistics.GetMinimum() {
+		*s.StringStatistics.Minimum = val
+	}
+}
+
 func (s *StringStatistics) Reset() {
 	*s = *NewStringStatistics()
 }
@@ -221,40 +362,217 @@ func (s *StringStatistics) Statistics() *proto.ColumnStatistics {
 	return s.ColumnStatistics
 }
 
+// BucketStatistics are the statistics of boolean columns, which record the
+// number of true values.
 type BucketStatistics struct {
 	BaseStatistics
 }
 
 func NewBucketStatistics() *BucketStatistics {
 	base := NewBaseStatistics()
-	base.BucketStatistics = &proto.BucketStatistics{}
+	base.BucketStatistics = &proto.BucketStatistics{
+		Count: []uint64{0},
+	}
 	return &BucketStatistics{
 		base,
 	}
 }
 
-// func (b *BucketStatistics) Add(value interface{}) {
-// 	if t, ok := value.(bool); ok {
-// 		b.BaseStatistics
-// 	}
-// 	b.BaseStatistics.Add(value)
-// }
+func (b *BucketStatistics) Add(value interface{}) {
+	if t, ok := value.(bool); ok && t {
+		b.BucketStatistics.Count[0]++
+	}
+	b.BaseStatistics.Add(value)
+}
 
-type TimestampStatistics struct {
+func (b *BucketStatistics) Merge(other ColumnStatistics) {
+	if bs, ok := other.(*BucketStatistics); ok {
+		b.BucketStatistics.Count[0] += bs.BucketStatistics.Count[0]
+		b.BaseStatistics.Merge(bs.BaseStatistics)
+	}
+}
+
+func (b *BucketStatistics) Statistics() *proto.ColumnStatistics {
+	return b.ColumnStatistics
+}
+
+func (b *BucketStatistics) Reset() {
+	*b = *NewBucketStatistics()
+}
+
+// DoubleStatistics are the statistics of float and double columns.
+type DoubleStatistics struct {
 	BaseStatistics
 	minSet bool
 }
 
-func NewTimestampStatistics() *TimestampStatistics {
+func NewDoubleStatistics() *DoubleStatistics {
+	base := NewBaseStatistics()
+	var sumValue float64
+	base.DoubleStatistics = &proto.DoubleStatistics{
+		Sum: &sumValue,
+	}
+	return &DoubleStatistics{
+		BaseStatistics: base,
+	}
+}
+
+func (d *DoubleStatistics) Merge(other ColumnStatistics) {
+	if ds, ok := other.(*DoubleStatistics); ok {
+		if ds.minSet {
+			d.addMinMax(ds.DoubleStatistics.GetMinimum())
+			d.addMinMax(ds.DoubleStatistics.GetMaximum())
+		}
+		sum := d.DoubleStatistics.GetSum() + ds.DoubleStatistics.GetSum()
+		*d.DoubleStatistics.Sum = sum
+		d.BaseStatistics.Merge(ds.BaseStatistics)
+	}
+}
+
+func (d *DoubleStatistics) Add(value interface{}) {
+	switch t := value.(type) {
+	case float64:
+		d.addDouble(t)
+	case Double:
+		d.addDouble(float64(t))
+	case float32:
+		d.addDouble(float64(t))
+	case Float:
+		d.addDouble(float64(t))
+	default:
+		d.BaseStatistics.Add(value)
+	}
+}
+
+func (d *DoubleStatistics) addDouble(val float64) {
+	d.addMinMax(val)
+	sum := d.DoubleStatistics.GetSum() + val
+	*d.DoubleStatistics.Sum = sum
+	d.BaseStatistics.addValue()
+}
+
+// addMinMax updates the minimum and maximum with the value. NaN values are not
+// included in the minimum and maximum, although they are included in the sum.
+func (d *DoubleStatistics) addMinMax(val float64) {
+	if math.IsNaN(val) {
+		return
+	}
+	if !d.minSet {
+		minCopy, maxCopy := val, val
+		d.DoubleStatistics.Minimum = &minCopy
+		d.DoubleStatistics.Maximum = &maxCopy
+		d.minSet = true
+		return
+	}
+	if val > d.DoubleStatistics.GetMaximum() {
+		*d.DoubleStatistics.Maximum = val
+	}
+	if val < d.DoubleStatistics.GetMinimum() {
+		*d.DoubleStatistics.Minimum = val
+	}
+}
+
+func (d *DoubleStatistics) Statistics() *proto.ColumnStatistics {
+	return d.ColumnStatistics
+}
+
+func (d *DoubleStatistics) Reset() {
+	*d = *NewDoubleStatistics()
+}
+
+// DateStatistics are the statistics of date columns, which record the minimum
+// and maximum number of days since the epoch.
+type DateStatistics struct {
+	BaseStatistics
+	minSet bool
+}
+
+func NewDateStatistics() *DateStatistics {
 	base := NewBaseStatistics()
-	var max, min, maxUTC, minUTC int64
+	base.DateStatistics = &proto.DateStatistics{}
+	return &DateStatistics{
+		BaseStatistics: base,
+	}
+}
+
+func (d *DateStatistics) Merge(other ColumnStatistics) {
+	if ds, ok := other.(*DateStatistics); ok {
+		if ds.minSet {
+			d.addMinMax(ds.DateStatistics.GetMinimum())
+			d.addMinMax(ds.DateStatistics.GetMaximum())
+		}
+		d.BaseStatistics.Merge(ds.BaseStatistics)
+	}
+}
+
+func (d *DateStatistics) Add(value interface{}) {
+	if val, ok := value.(time.Time); ok {
+		d.addInt(daysSinceEpoch(val))
+		return
+	}
+	d.BaseStatistics.Add(value)
+}
+
+// addInt adds a date given as the number of days since the epoch.
+func (d *DateStatistics) addInt(days int64) {
+	d.addMinMax(int32(days))
+	d.BaseStatistics.addValue()
+}
 
-	base.TimestampStatistics = &proto.TimestampStatistics{
-		Maximum:    &max,
-		Minimum:    &min,
-		MaximumUtc: &maxUTC,
-		MinimumUtc: &minUTC,
+// addInts adds the minimum and maximum of the numbers of days to the statistics.
+func (d *DateStatistics) addInts(days []int64) {
+	if len(days) == 0 {
+		return
 	}
+	min, max := days[0], days[0]
+	for _, day := range days {
+		if day < min {
+			min = day
+		} else if day > max {
+			max = day
+		}
+	}
+	d.addMinMax(int32(min))
+	d.addMinMax(int32(max))
+	d.BaseStatistics.addValues(len(days))
+}
+
+// addMinMax updates the minimum and maximum with the number of days.
+func (d *DateStatistics) addMinMax(days int32) {
+	if !d.minSet {
+		minCopy, maxCopy := days, days
+		d.DateStatistics.Minimum = &minCopy
+		d.DateStatistics.Maximum = &maxCopy
+		d.minSet = true
+		return
+	}
+	if days > d.DateStatistics.GetMaximum() {
+		*d.DateStatistics.Maximum = days
+	}
+	if days < d.DateStatistics.GetMinimum() {
+		*d.DateStatistics.Minimum = days
+	}
+}
+
+func (d *DateStatistics) Statistics() *proto.ColumnStatistics {
+	return d.ColumnStatistics
+}
+
+func (d *DateStatistics) Reset() {
+	*d = *NewDateStatistics()
+}
+
+type TimestampStatistics struct {
+	BaseStatistics
+	minSet bool
+	maxSet bool
+}
+
+func NewTimestampStatistics() *TimestampStatistics {
+	base := NewBaseStatistics()
+	// The minimum and maximum are set once a value is added, so that they
+	// are not recorded for columns without values.
+	base.TimestampStatistics = &proto.TimestampStatistics{}
 	return &TimestampStatistics{
 		BaseStatistics: base,
 	}
@@ -262,44 +580,50 @@ func NewTimestampStatistics() *TimestampStatistics {
 
 func (i *TimestampStatistics) Merge(other ColumnStatistics) {
 	if is, ok := other.(*TimestampStatistics); ok {
-		if is.TimestampStatistics.GetMaximum() > i.TimestampStatistics.GetMaximum() {
-			i.TimestampStatistics.Maximum = is.TimestampStatistics.Maximum
-			i.TimestampStatistics.MaximumUtc = is.TimestampStatistics.MaximumUtc
-		}
-		if is.TimestampStatistics.GetMinimum() < i.TimestampStatistics.GetMinimum() {
-			i.TimestampStatistics.Minimum = is.TimestampStatistics.Minimum
-			i.TimestampStatistics.MinimumUtc = is.TimestampStatistics.MinimumUtc
+		if is.minSet {
+			i.addMillis(is.TimestampStatistics.GetMinimum(), is.TimestampStatistics.GetMinimumUtc())
+			i.addMillis(is.TimestampStatistics.GetMaximum(), is.TimestampStatistics.GetMaximumUtc())
 		}
 		i.BaseStatistics.Merge(is.BaseStatistics)
 	}
 }
 
+// Add adds a time.Time, or nil for a null value, to the statistics. The time
+// should be in the location of the writer timezone.
 func (i *TimestampStatistics) Add(value interface{}) {
 	if val, ok := value.(time.Time); ok {
-		if i.TimestampStatistics.Maximum == nil {
-			valCopy := val.Unix()
-			valUTCCopy := val.UTC().Unix()
-			i.TimestampStatistics.Maximum = &valCopy
-			i.TimestampStatistics.MaximumUtc = &valUTCCopy
-		} else if val.After(time.Unix(i.TimestampStatistics.GetMaximum(), 0)) {
-			*i.TimestampStatistics.Maximum = val.Unix()
-			*i.TimestampStatistics.MaximumUtc = val.UTC().Unix()
-		}
-		if !i.minSet {
-			valCopy := val.Unix()
-			valUTCCopy := val.UTC().Unix()
-			i.TimestampStatistics.Minimum = &valCopy
-			i.TimestampStatistics.MinimumUtc = &valUTCCopy
-			i.minSet = true
-		} else if val.Before(time.Unix(i.TimestampStatistics.GetMinimum(), 0)) {
-			*i.TimestampStatistics.Minimum = val.Unix()
-			*i.TimestampStatistics.MinimumUtc = val.UTC().Unix()
-
-		}
+		_, offset := val.Zone()
+		utc := timestampMillis(val)
+		i.addMillis(utc+int64(offset)*1000, utc)
 	}
 	i.BaseStatistics.Add(value)
 }
 
+// addMillis updates the minimum and maximum with the milliseconds since the
+// epoch of the wall clock time in the writer timezone, local, and in UTC.
+func (i *TimestampStatistics) addMillis(local, utc int64) {
+	if !i.maxSet {
+		valCopy := local
+		valUTCCopy := utc
+		i.TimestampStatistics.Maximum = &valCopy
+		i.TimestampStatistics.MaximumUtc = &valUTCCopy
+		i.maxSet = true
+	} else if utc > i.TimestampStatistics.GetMaximumUtc() {
+		*i.TimestampStatistics.Maximum = local
+		*i.TimestampStatistics.MaximumUtc = utc
+	}
+	if !i.minSet {
+		valCopy := local
+		valUTCCopy := utc
+		i.TimestampStatistics.Minimum = &valCopy
+		i.TimestampStatistics.MinimumUtc = &valUTCCopy
+		i.minSet = true
+	} else if utc < i.TimestampStatistics.GetMinimumUtc() {
+		*i.TimestampStatistics.Minimum = local
+		*i.TimestampStatistics.MinimumUtc = utc
+	}
+}
+
 func (i *TimestampStatistics) Statistics() *proto.ColumnStatistics {
 	return i.ColumnStatistics
 }
@@ -307,3 +631,144 @@ func (i *TimestampStatistics) Statistics() *proto.ColumnStatistics {
 func (i *TimestampStatistics) Reset() {
 	*i = *NewTimestampStatistics()
 }
+
+// timestampMillis returns the milliseconds since the epoch of the time, which is
+// the unit of timestamp statistics.
+func timestampMillis(t time.Time) int64 {
+	return t.Unix()*1000 + int64(t.Nanosecond())/int64(time.Millisecond)
+}
+
+// daysSinceEpoch returns the number of days since the epoch of the date, which
+// is how dates are written and the unit of date statistics.
+func daysSinceEpoch(t time.Time) int64 {
+	return t.Truncate(24*time.Hour).Unix() / 86400
+}
+
+// timeFromMillis returns the time of the milliseconds since the epoch in UTC.
+func timeFromMillis(millis int64) time.Time {
+	return time.Unix(millis/1000, (millis%1000)*int64(time.Millisecond)).UTC()
+}
+
+type BinaryStatistics struct {
+	BaseStatistics
+}
+
+func NewBinaryStatistics() *BinaryStatistics {
+	base := NewBaseStatistics()
+	var sumValue int64
+	base.BinaryStatistics = &proto.BinaryStatistics{
+		Sum: &sumValue,
+	}
+	return &BinaryStatistics{
+		BaseStatistics: base,
+	}
+}
+
+func (b *BinaryStatistics) Merge(other ColumnStatistics) {
+	if bs, ok := other.(*BinaryStatistics); ok {
+		sum := b.BinaryStatistics.GetSum() + bs.BinaryStatistics.GetSum()
+		*b.BinaryStatistics.Sum = sum
+		b.BaseStatistics.Merge(bs.BaseStatistics)
+	}
+}
+
+func (b *BinaryStatistics) Add(value interface{}) {
+	if val, ok := value.([]byte); ok {
+		sum := b.BinaryStatistics.GetSum() + int64(len(val))
+		*b.BinaryStatistics.Sum = sum
+	}
+	b.BaseStatistics.Add(value)
+}
+
+func (b *BinaryStatistics) Statistics() *proto.ColumnStatistics {
+	return b.ColumnStatistics
+}
+
+func (b *BinaryStatistics) Reset() {
+	*b = *NewBinaryStatistics()
+}
+
+type DecimalStatistics struct {
+	BaseStatistics
+	min        *Decimal
+	max        *Decimal
+	sum        *big.Rat
+	sumScale   int64
+	sumInvalid bool
+}
+
+func NewDecimalStatistics() *DecimalStatistics {
+	base := NewBaseStatistics()
+	base.DecimalStatistics = &proto.DecimalStatistics{}
+	return &DecimalStatistics{
+		BaseStatistics: base,
+		sum:            new(big.Rat),
+	}
+}
+
+func (d *DecimalStatistics) Merge(other ColumnStatistics) {
+	if ds, ok := other.(*DecimalStatistics); ok {
+		if ds.max != nil {
+			d.updateMax(*ds.max)
+		}
+		if ds.min != nil {
+			d.updateMin(*ds.min)
+		}
+		if ds.sumInvalid {
+			d.sumInvalid = true
+		} else {
+			d.updateSum(ds.sum, ds.sumScale)
+		}
+		d.BaseStatistics.Merge(ds.BaseStatistics)
+	}
+}
+
+func (d *DecimalStatistics) Add(value interface{}) {
+	if val, ok := value.(Decimal); ok {
+		d.updateMax(val)
+		d.updateMin(val)
+		d.updateSum(val.Rat(), val.Scale)
+	}
+	d.BaseStatistics.Add(value)
+}
+
+func (d *DecimalStatistics) updateMax(val Decimal) {
+	if d.max == nil || val.Rat().Cmp(d.max.Rat()) > 0 {
+		d.max = &val
+		d.DecimalStatistics.Maximum = ptrStr(val.String())
+	}
+}
+
+func (d *DecimalStatistics) updateMin(val Decimal) {
+	if d.min == nil || val.Rat().Cmp(d.min.Rat()) < 0 {
+		d.min = &val
+		d.DecimalStatistics.Minimum = ptrStr(val.String())
+	}
+}
+
+// updateSum adds r to the running sum. The sum is discarded once it
+// can no longer be represented using the maximum decimal precision.
+func (d *DecimalStatistics) updateSum(r *big.Rat, scale int64) {
+	if d.sumInvalid {
+		return
+	}
+	if scale > d.sumScale {
+		d.sumScale = scale
+	}
+	d.sum.Add(d.sum, r)
+	sum := decimalFromRat(d.sum, d.sumScale)
+	if sum.Precision() > maxPrecision {
+		d.sumInvalid = true
+		d.DecimalStatistics.Sum = nil
+		return
+	}
+	d.DecimalStatistics.Sum = ptrStr(sum.String())
+}
+
+func (d *DecimalStatistics) Statistics() *proto.ColumnStatistics {
+	return d.ColumnStatistics
+}
+
+func (d *DecimalStatistics) Reset() {
+	*d = *NewDecimalStatistics()
+}
@@ -4,12 +4,21 @@ import (
 	"bytes"
 	"compress/flate"
 	"encoding/binary"
+	"errors"
 	"io"
 	"io/ioutil"
+	"runtime"
+	"sync"
 
 	"fmt"
 
 	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	errCorruptChunkHeader  = errors.New("corrupt compression chunk header")
+	errLZOWriteUnsupported = errors.New("LZO compression is not supported for writing")
 )
 
 // CompressionCodec is an interface that provides methods for creating
@@ -222,9 +231,15 @@ func (c *CompressionZlibEncoder) flush() error {
 // CompressionSnappy implements the CompressionCodec for Snappy compression.
 type CompressionSnappy struct{}
 
-// Encoder implements the CompressionCodec interface. This is currently not implemented.
+// Encoder implements the CompressionCodec interface.
 func (c CompressionSnappy) Encoder(w io.Writer) io.WriteCloser {
-	return &CompressionSnappyEncoder{w}
+	return &CompressionSnappyEncoder{
+		chunkEncoder{
+			destination: w,
+			chunkSize:   int(DefaultCompressionChunkSize),
+			compress:    snappyEncode,
+		},
+	}
 }
 
 // Decoder implements the CompressionCodec interface.
@@ -283,20 +298,298 @@ func (c *CompressionSnappyDecoder) Read(p []byte) (int, error) {
 	return n, err
 }
 
+// CompressionSnappyEncoder implements the encoder for CompressionSnappy.
 type CompressionSnappyEncoder struct {
-	w io.Writer
+	chunkEncoder
+}
+
+// snappyEncode compresses src using snappy, reusing the capacity of dst where possible.
+func snappyEncode(dst, src []byte) []byte {
+	return snappy.Encode(dst[:cap(dst)], src)
+}
+
+// CompressionLZ4 implements the CompressionCodec for LZ4 compression.
+type CompressionLZ4 struct{}
+
+// Encoder implements the CompressionCodec interface.
+func (c CompressionLZ4) Encoder(w io.Writer) io.WriteCloser {
+	return &CompressionLZ4Encoder{
+		chunkEncoder{
+			destination: w,
+			chunkSize:   int(DefaultCompressionChunkSize),
+			compress:    lz4Encode,
+		},
+	}
+}
+
+// Decoder implements the CompressionCodec interface.
+func (c CompressionLZ4) Decoder(r io.Reader) io.Reader {
+	return &CompressionLZ4Decoder{
+		chunkDecoder{
+			source:     r,
+			decompress: lz4Decode,
+		},
+	}
+}
+
+// CompressionLZ4Encoder implements the encoder for CompressionLZ4.
+type CompressionLZ4Encoder struct {
+	chunkEncoder
+}
+
+// CompressionLZ4Decoder implements the decoder for CompressionLZ4.
+type CompressionLZ4Decoder struct {
+	chunkDecoder
+}
+
+// CompressionLZO implements the CompressionCodec for LZO compression. It can only
+// be used to read LZO compressed files, its Encoder returns an error on write.
+type CompressionLZO struct{}
+
+// Encoder implements the CompressionCodec interface.
+func (c CompressionLZO) Encoder(w io.Writer) io.WriteCloser {
+	return &CompressionLZOEncoder{}
+}
+
+// Decoder implements the CompressionCodec interface.
+func (c CompressionLZO) Decoder(r io.Reader) io.Reader {
+	return &CompressionLZODecoder{
+		chunkDecoder{
+			source:     r,
+			decompress: lzoDecode,
+		},
+	}
+}
+
+// CompressionLZOEncoder implements the encoder for CompressionLZO. Writing
+// LZO is not supported so all writes return an error.
+type CompressionLZOEncoder struct{}
+
+func (c *CompressionLZOEncoder) Write(p []byte) (int, error) {
+	return 0, errLZOWriteUnsupported
+}
+
+func (c *CompressionLZOEncoder) Close() error {
+	return nil
+}
+
+// CompressionLZODecoder implements the decoder for CompressionLZO.
+type CompressionLZODecoder struct {
+	chunkDecoder
+}
+
+// CompressionZstd implements the CompressionCodec for Zstandard compression. Level
+// is the zstd compression level, a value of zero uses the default level.
+type CompressionZstd struct {
+	Level int
+}
+
+// Encoder implements the CompressionCodec interface.
+func (c CompressionZstd) Encoder(w io.Writer) io.WriteCloser {
+	enc, err := zstdEncoder(c.Level)
+	return &CompressionZstdEncoder{
+		chunkEncoder: chunkEncoder{
+			destination: w,
+			chunkSize:   int(DefaultCompressionChunkSize),
+			compress: func(dst, src []byte) []byte {
+				return enc.EncodeAll(src, dst)
+			},
+		},
+		err: err,
+	}
+}
+
+// Decoder implements the CompressionCodec interface.
+func (c CompressionZstd) Decoder(r io.Reader) io.Reader {
+	return &CompressionZstdDecoder{
+		chunkDecoder{
+			source: r,
+			decompress: func(dst, src []byte) ([]byte, error) {
+				dec, err := zstdDecoder()
+				if err != nil {
+					return nil, err
+				}
+				return dec.DecodeAll(src, dst)
+			},
+		},
+	}
+}
+
+// CompressionZstdEncoder implements the encoder for CompressionZstd.
+type CompressionZstdEncoder struct {
+	chunkEncoder
+	err error
 }
 
-func (c *CompressionSnappyEncoder) Write(p []byte) (int, error) {
-	return 0, fmt.Errorf("Not implemented")
+func (c *CompressionZstdEncoder) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	return c.chunkEncoder.Write(p)
 }
 
-func (c *CompressionSnappyEncoder) Close() error {
-	return fmt.Errorf("Not implemented")
+// CompressionZstdDecoder implements the decoder for CompressionZstd.
+type CompressionZstdDecoder struct {
+	chunkDecoder
 }
 
-func (c *CompressionSnappyEncoder) Flush() error {
-	return fmt.Errorf("Not implemented")
+var (
+	// zstdEncoders holds a *zstd.Encoder for each compression level. Encoders are
+	// safe for concurrent use with EncodeAll and are expensive to create, so
+	// they are shared between all CompressionZstdEncoders and allow as many
+	// concurrent calls as there are processors.
+	zstdEncoders   sync.Map
+	zstdDecoderVal *zstd.Decoder
+	zstdDecoderErr error
+	zstdDecoderOne sync.Once
+)
+
+func zstdEncoder(level int) (*zstd.Encoder, error) {
+	if enc, ok := zstdEncoders.Load(level); ok {
+		return enc.(*zstd.Encoder), nil
+	}
+	encoderLevel := zstd.SpeedDefault
+	if level != 0 {
+		encoderLevel = zstd.EncoderLevelFromZstd(level)
+	}
+	enc, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(encoderLevel), zstd.WithEncoderConcurrency(runtime.GOMAXPROCS(0)))
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := zstdEncoders.LoadOrStore(level, enc)
+	return actual.(*zstd.Encoder), nil
+}
+
+func zstdDecoder() (*zstd.Decoder, error) {
+	zstdDecoderOne.Do(func() {
+		zstdDecoderVal, zstdDecoderErr = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+	})
+	return zstdDecoderVal, zstdDecoderErr
+}
+
+// chunkEncoder splits written bytes into chunks of at most chunkSize bytes, each
+// of which is compressed using compress and prefixed with an ORC chunk header.
+// Chunks that do not benefit from compression are stored in their original form.
+type chunkEncoder struct {
+	destination io.Writer
+	chunkSize   int
+	compress    func(dst, src []byte) []byte
+	rawBuffer   []byte
+	compressed  []byte
+}
+
+func (c *chunkEncoder) Write(p []byte) (int, error) {
+	c.rawBuffer = append(c.rawBuffer, p...)
+	for len(c.rawBuffer) >= c.chunkSize {
+		if err := c.writeChunk(c.rawBuffer[:c.chunkSize]); err != nil {
+			return 0, err
+		}
+		c.rawBuffer = c.rawBuffer[c.chunkSize:]
+	}
+	return len(p), nil
+}
+
+// writeChunk compresses the chunk and writes it to the destination along with its header.
+func (c *chunkEncoder) writeChunk(chunk []byte) error {
+	c.compressed = c.compress(c.compressed[:0], chunk)
+	out := c.compressed
+	isOriginal := len(out) >= len(chunk)
+	if isOriginal {
+		out = chunk
+	}
+	header, err := compressionHeader(len(out), isOriginal)
+	if err != nil {
+		return err
+	}
+	if _, err := c.destination.Write(header); err != nil {
+		return err
+	}
+	n, err := c.destination.Write(out)
+	if err != nil {
+		return err
+	}
+	if n != len(out) {
+		return fmt.Errorf("Expected to write %d bytes, wrote %d", len(out), n)
+	}
+	return nil
+}
+
+// Flush writes any buffered bytes to the destination as a single chunk.
+func (c *chunkEncoder) Flush() error {
+	if len(c.rawBuffer) == 0 {
+		return nil
+	}
+	if err := c.writeChunk(c.rawBuffer); err != nil {
+		return err
+	}
+	c.rawBuffer = c.rawBuffer[:0]
+	return nil
+}
+
+func (c *chunkEncoder) Close() error {
+	return c.Flush()
+}
+
+// chunkDecoder reads ORC chunks from source, decompressing each using decompress
+// unless the chunk header indicates that it is stored in its original form.
+type chunkDecoder struct {
+	source       io.Reader
+	decompress   func(dst, src []byte) ([]byte, error)
+	decoded      io.Reader
+	compressed   []byte
+	decompressed []byte
+}
+
+func (c *chunkDecoder) readHeader() error {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(c.source, header[:3]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return errCorruptChunkHeader
+		}
+		return err
+	}
+	headerVal := binary.LittleEndian.Uint32(header)
+	chunkLength := int(headerVal / 2)
+	if cap(c.compressed) < chunkLength {
+		c.compressed = make([]byte, chunkLength)
+	}
+	c.compressed = c.compressed[:chunkLength]
+	if _, err := io.ReadFull(c.source, c.compressed); err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	if headerVal%2 == 1 {
+		c.decoded = bytes.NewReader(c.compressed)
+		return nil
+	}
+	decompressed, err := c.decompress(c.decompressed[:0], c.compressed)
+	if err != nil {
+		return err
+	}
+	c.decompressed = decompressed
+	c.decoded = bytes.NewReader(decompressed)
+	return nil
+}
+
+func (c *chunkDecoder) Read(p []byte) (int, error) {
+	for {
+		if c.decoded == nil {
+			if err := c.readHeader(); err != nil {
+				return 0, err
+			}
+		}
+		n, err := c.decoded.Read(p)
+		if err == io.EOF {
+			c.decoded = nil
+			if n == 0 {
+				continue
+			}
+			return n, nil
+		}
+		return n, err
+	}
 }
 
 func compressionHeader(chunkLength int, isOriginal bool) ([]byte, error) {
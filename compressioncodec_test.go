@@ -12,10 +12,17 @@ var (
 	_ CompressionCodec = (*CompressionNone)(nil)
 	_ CompressionCodec = (*CompressionSnappy)(nil)
 	_ CompressionCodec = (*CompressionZlib)(nil)
+	_ CompressionCodec = (*CompressionLZ4)(nil)
+	_ CompressionCodec = (*CompressionZstd)(nil)
+	_ CompressionCodec = (*CompressionLZO)(nil)
 
 	_ io.WriteCloser = (*CompressionZlibEncoder)(nil)
 	_ io.WriteCloser = (*CompressionZlibEncoder)(nil)
 	_ io.WriteCloser = (*CompressionZlibEncoder)(nil)
+	_ io.WriteCloser = (*CompressionSnappyEncoder)(nil)
+	_ io.WriteCloser = (*CompressionLZ4Encoder)(nil)
+	_ io.WriteCloser = (*CompressionZstdEncoder)(nil)
+	_ io.WriteCloser = (*CompressionLZOEncoder)(nil)
 )
 
 func TestCompressionHeader(t *testing.T) {
@@ -81,3 +88,213 @@ func TestCompressionZlib(t *testing.T) {
 		t.Errorf("Input and output don't match: %v vs %v", buf, got)
 	}
 }
+
+func TestCompressionSnappy(t *testing.T) {
+	c := CompressionSnappy{}
+
+	// Use a mixture of incompressible and compressible data spanning
+	// multiple chunks so that both original and compressed chunks are written.
+	random := make([]byte, 1<<17)
+	_, err := rand.Read(random)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := append(random, bytes.Repeat([]byte("orc"), 1<<18)...)
+
+	w := &bytes.Buffer{}
+	r := w
+
+	enc := c.Encoder(w)
+	dec := c.Decoder(r)
+
+	n, err := enc.Write(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(buf) {
+		t.Errorf("Buffer underflow. Expected to write %d, wrote %d", len(buf), n)
+	}
+	err = enc.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if w.Len() >= len(buf) {
+		t.Errorf("Expected compressed length to be less than %d, got %d", len(buf), w.Len())
+	}
+
+	got, err := ioutil.ReadAll(dec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Compare(buf, got) != 0 {
+		t.Errorf("Input and output don't match")
+	}
+}
+
+func TestCompressionLZ4(t *testing.T) {
+	c := CompressionLZ4{}
+
+	random := make([]byte, 1<<17)
+	_, err := rand.Read(random)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := append(random, bytes.Repeat([]byte("orc"), 1<<18)...)
+
+	w := &bytes.Buffer{}
+	r := w
+
+	enc := c.Encoder(w)
+	dec := c.Decoder(r)
+
+	n, err := enc.Write(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(buf) {
+		t.Errorf("Buffer underflow. Expected to write %d, wrote %d", len(buf), n)
+	}
+	err = enc.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if w.Len() >= len(buf) {
+		t.Errorf("Expected compressed length to be less than %d, got %d", len(buf), w.Len())
+	}
+
+	got, err := ioutil.ReadAll(dec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Compare(buf, got) != 0 {
+		t.Errorf("Input and output don't match")
+	}
+}
+
+func TestLZ4Block(t *testing.T) {
+	testCases := [][]byte{
+		{},
+		[]byte("a"),
+		[]byte("abcdefghijklm"),
+		bytes.Repeat([]byte{0}, 1000),
+		bytes.Repeat([]byte("abcdefgh"), 10000),
+		[]byte("the quick brown fox jumps over the lazy dog, the quick brown fox jumps over the lazy dog"),
+	}
+
+	for _, tc := range testCases {
+		compressed := lz4Encode(nil, tc)
+		got, err := lz4Decode(nil, compressed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(tc, got) {
+			t.Errorf("Input and output don't match for input of length %d", len(tc))
+		}
+	}
+
+	if _, err := lz4Decode(nil, []byte{0x1f, 'a', 0x05, 0x00}); err == nil {
+		t.Errorf("Expected an error decoding a match with an invalid offset")
+	}
+}
+
+func TestCompressionLZ4TruncatedChunk(t *testing.T) {
+	header, err := compressionHeader(10, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := bytes.NewReader(append(header, []byte("orc")...))
+	_, err = ioutil.ReadAll(CompressionLZ4{}.Decoder(r))
+	if err != io.ErrUnexpectedEOF {
+		t.Errorf("Expected %v, got %v", io.ErrUnexpectedEOF, err)
+	}
+}
+
+func TestLZOBlock(t *testing.T) {
+	testCases := []struct {
+		input    []byte
+		expected []byte
+	}{
+		{
+			// Three literals followed by an M2 match.
+			input:    []byte{20, 'a', 'b', 'c', 72, 0, 0x11, 0, 0},
+			expected: []byte("abcabc"),
+		},
+		{
+			// Three literals followed by an M3 match and a trailing literal.
+			input:    []byte{20, 'a', 'b', 'c', 39, 0x09, 0, 'd', 0x11, 0, 0},
+			expected: []byte("abcabcabcabcd"),
+		},
+		{
+			// A literal run with an extended length.
+			input:    append([]byte{0, 0, 1}, append(bytes.Repeat([]byte("z"), 274), 0x11, 0, 0)...),
+			expected: bytes.Repeat([]byte("z"), 274),
+		},
+	}
+
+	for _, tc := range testCases {
+		got, err := lzoDecode(nil, tc.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(tc.expected, got) {
+			t.Errorf("Expected %q, got %q", tc.expected, got)
+		}
+	}
+
+	if _, err := lzoDecode(nil, []byte{20, 'a', 'b', 'c', 72, 1, 0x11, 0, 0}); err == nil {
+		t.Errorf("Expected an error decoding a match with an invalid offset")
+	}
+
+	enc := CompressionLZO{}.Encoder(&bytes.Buffer{})
+	if _, err := enc.Write([]byte("abc")); err == nil {
+		t.Errorf("Expected an error writing LZO compressed data")
+	}
+}
+
+func TestCompressionZstd(t *testing.T) {
+	for _, level := range []int{0, 1, 19} {
+		c := CompressionZstd{Level: level}
+
+		random := make([]byte, 1<<17)
+		_, err := rand.Read(random)
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf := append(random, bytes.Repeat([]byte("orc"), 1<<18)...)
+
+		w := &bytes.Buffer{}
+		r := w
+
+		enc := c.Encoder(w)
+		dec := c.Decoder(r)
+
+		n, err := enc.Write(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != len(buf) {
+			t.Errorf("Buffer underflow. Expected to write %d, wrote %d", len(buf), n)
+		}
+		err = enc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if w.Len() >= len(buf) {
+			t.Errorf("Expected compressed length to be less than %d, got %d", len(buf), w.Len())
+		}
+
+		got, err := ioutil.ReadAll(dec)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if bytes.Compare(buf, got) != 0 {
+			t.Errorf("Input and output don't match for level %d", level)
+		}
+	}
+}
@@ -22,11 +22,37 @@ type Cursor struct {
 	currentRow   int
 	err          error
 	stripeOffset int
+	predicate    Predicate
+	// columnReaders holds the TreeReaders of the selected columns and their
+	// children by column ID.
+	columnReaders map[int]TreeReader
+	// rowIndexes holds the row index of each column in columnReaders once
+	// they have been loaded to seek the readers of the current stripe.
+	rowIndexes map[int]*proto.RowIndex
+	// rowGroups records whether each row group of the current stripe might
+	// satisfy the predicate, it is nil if all row groups are read.
+	rowGroups []bool
+	// pipeline decodes stripes ahead of the Cursor when the Reader has
+	// concurrency enabled.
+	pipeline *stripePipeline
+	// decoded is the current stripe when its rows are decoded by the
+	// pipeline, in which case the Cursor has no readers.
+	decoded *preparedStripe
+	// batch holds the rows decoded by the pipeline that are being consumed,
+	// and batchRow is the index of the current row within it.
+	batch    *Batch
+	batchRow int
+	// fields holds the names that the columns were selected by.
+	fields []string
+	// evolution maps each column to the file schema when the columns were
+	// selected using a reader schema, otherwise it is nil.
+	evolution []*schemaEvolution
 }
 
 // Select determines the columns that will be read from the ORC file.
 // Only streams for the selected columns will be loaded into memory.
 func (c *Cursor) Select(fields ...string) *Cursor {
+	c.closePipeline()
 	var columns []*TypeDescription
 	var included []int
 	for _, field := range fields {
@@ -41,12 +67,72 @@ func (c *Cursor) Select(fields ...string) *Cursor {
 	}
 	c.columns = columns
 	c.included = included
+	c.fields = fields
+	c.evolution = nil
 	return c
 }
 
+// Where sets a Predicate that is evaluated against the statistics of each stripe.
+// Stripes whose statistics show that none of their rows can satisfy the predicate
+// are skipped by Stripes. Within the remaining stripes, the predicate is evaluated
+// against the statistics of each row group in the row index and row groups that
+// cannot satisfy it are skipped by Next without being decoded. Rows within the
+// remaining row groups are not filtered, so some may not satisfy the predicate.
+// If the columns were selected using SelectWithSchema, the predicate refers to
+// the fields of the reader schema, which must be selected before Where is called.
+func (c *Cursor) Where(predicate Predicate) *Cursor {
+	for _, column := range predicate.columns() {
+		if _, _, err := c.predicateColumn(column); err != nil {
+			c.err = err
+			return c
+		}
+	}
+	c.closePipeline()
+	c.predicate = predicate
+	return c
+}
+
+// predicateColumn returns the column of the file whose statistics are used to
+// evaluate predicates on the named column. If the columns were selected using a
+// reader schema, the name refers to a field of the reader schema and false is
+// returned if the statistics of the file cannot be used for it.
+func (c *Cursor) predicateColumn(column string) (*TypeDescription, bool, error) {
+	if c.evolution == nil {
+		td, err := c.Reader.schema.GetField(column)
+		if err != nil {
+			return nil, false, err
+		}
+		return td, true, nil
+	}
+	e := evolvedField(c.evolution, c.fields, column)
+	if e == nil {
+		return nil, false, fmt.Errorf("no field with name: %s", column)
+	}
+	td := e.statisticsColumn()
+	return td, td != nil, nil
+}
+
+// stripeMightMatch returns false if the statistics of stripe n show that none
+// of its rows can satisfy the predicate of the Cursor.
+func (c *Cursor) stripeMightMatch(n int) bool {
+	stripeStats := c.Reader.metadata.GetStripeStats()
+	if c.predicate == nil || n >= len(stripeStats) {
+		return true
+	}
+	colStats := stripeStats[n].GetColStats()
+	return c.predicate.mightMatch(func(column string) (*proto.ColumnStatistics, *TypeDescription, bool) {
+		td, ok, err := c.predicateColumn(column)
+		if err != nil || !ok || td.getID() >= len(colStats) {
+			return nil, nil, false
+		}
+		return colStats[td.getID()], td, true
+	})
+}
+
 // SelectStripe retrieves the stream information for the specified stripe.
 func (c *Cursor) SelectStripe(n int) error {
-	stripe, err := c.Reader.getStripe(n, c.included...)
+	c.closePipeline()
+	stripe, err := c.Reader.getStripe(n, c.included, c.indexed())
 	if err != nil {
 		return err
 	}
@@ -55,18 +141,291 @@ func (c *Cursor) SelectStripe(n int) error {
 	return c.prepareStreamReaders()
 }
 
+// SeekRow positions the Cursor at row n of the file, counting from zero, so that
+// the following call to Next returns it. The readers of the selected columns
+// are moved to the start of the row group containing the row using the row
+// index, when the file has one, and the preceding rows of the row group are
+// skipped. Once the rows of the stripe are exhausted, Stripes continues from
+// the following stripe. Rows may also be sought after Stripes has returned
+// false at the end of the file. If a predicate has been set using Where and the
+// row group containing the row cannot satisfy it, Next returns the first row of
+// the next row group that might.
+func (c *Cursor) SeekRow(n int64) error {
+	if c.err != nil && c.err != io.EOF {
+		return c.err
+	}
+	c.closePipeline()
+	stripes, err := c.Reader.getStripes()
+	if err != nil {
+		return err
+	}
+	if n < 0 {
+		return fmt.Errorf("row %v is out of range", n)
+	}
+	row := n
+	for i, stripe := range stripes {
+		numRows := int64(stripe.GetNumberOfRows())
+		if row < numRows {
+			// Clear io.EOF if every stripe has been read.
+			c.err = nil
+			if err := c.seekRow(stripes, i, int(row)); err != nil {
+				c.err = err
+				return err
+			}
+			return nil
+		}
+		row -= numRows
+	}
+	return fmt.Errorf("row %v is out of range", n)
+}
+
+// seekRow positions the Cursor at the provided row of the stripe, loading the
+// stripe if it is not the current stripe.
+func (c *Cursor) seekRow(stripes []*proto.StripeInformation, stripeNum int, row int) error {
+	// The readers of a stripe decoded by the pipeline are not available, so
+	// the stripe is loaded again.
+	if c.Stripe == nil || c.Stripe.StripeInformation != stripes[stripeNum] || c.readers == nil {
+		stripe, err := c.Reader.getStripe(stripeNum, c.included, c.indexed())
+		if err != nil {
+			return err
+		}
+		c.Stripe = stripe
+		if err := c.prepareStreamReaders(); err != nil {
+			return err
+		}
+		c.currentRow = 0
+	}
+	c.stripeOffset = stripeNum + 1
+	stride := int(c.Reader.footer.GetRowIndexStride())
+	if c.rowGroups != nil && !c.rowGroups[row/stride] {
+		// Position the Cursor at the start of the row group so that next
+		// skips it along with any following row groups that cannot satisfy
+		// the predicate.
+		row -= row % stride
+	}
+	// The readers only need to be moved if the row precedes the current row or
+	// is in a later row group, otherwise the rows in between are skipped.
+	if row < c.currentRow || (stride > 0 && row/stride != c.currentRow/stride) {
+		if c.rowIndexes == nil {
+			rowIndexes, err := c.loadRowIndexes()
+			if err != nil {
+				return err
+			}
+			c.rowIndexes = rowIndexes
+		}
+		if c.rowIndexes != nil {
+			if err := c.seekRowGroup(row / stride); err != nil {
+				return err
+			}
+			c.currentRow = row - row%stride
+		} else if row < c.currentRow {
+			// Without a row index the readers are recreated to read the
+			// stripe from the start.
+			if err := c.prepareStreamReaders(); err != nil {
+				return err
+			}
+			c.currentRow = 0
+		}
+	}
+	// Rows are skipped using Next as the readers of compound types only read
+	// the values of their children when their own value is read.
+	for c.currentRow < row {
+		if !c.Next() {
+			if err := c.Err(); err != nil {
+				return err
+			}
+			return fmt.Errorf("unable to skip to row %v of stripe %v", row, stripeNum)
+		}
+	}
+	c.nextVal = nil
+	return nil
+}
+
 // prepareStreamReaders prepares TreeReaders for each of the columns
 // that will be read.
 func (c *Cursor) prepareStreamReaders() error {
 	var readers []TreeReader
-	for _, column := range c.columns {
-		reader, err := createTreeReader(column, c.Stripe)
+	columnReaders := make(map[int]TreeReader)
+	for i, column := range c.columns {
+		var reader TreeReader
+		var err error
+		if c.evolution != nil {
+			reader, err = createEvolvedTreeReader(c.evolution[i], c.Stripe, columnReaders, c.Reader.trimCharPadding, c.Reader.location)
+		} else {
+			reader, err = createTreeReader(column, c.Stripe, columnReaders, c.Reader.trimCharPadding, c.Reader.location)
+		}
 		if err != nil {
 			return err
 		}
 		readers = append(readers, reader)
 	}
 	c.readers = readers
+	c.columnReaders = columnReaders
+	return c.selectRowGroups()
+}
+
+// indexed returns the IDs of the columns used by the predicate, whose row
+// indexes are loaded with each stripe.
+func (c *Cursor) indexed() []int {
+	if c.predicate == nil {
+		return nil
+	}
+	var indexed []int
+	for _, column := range c.predicate.columns() {
+		if td, ok, err := c.predicateColumn(column); err == nil && ok {
+			indexed = append(indexed, td.getID())
+		}
+	}
+	return indexed
+}
+
+// numRowGroups returns the number of row groups in the current stripe, or zero
+// if the file has no row index.
+func (c *Cursor) numRowGroups() int {
+	stride := int(c.Reader.footer.GetRowIndexStride())
+	if stride == 0 {
+		return 0
+	}
+	return (int(c.Stripe.GetNumberOfRows()) + stride - 1) / stride
+}
+
+// loadRowIndexes returns the row index of each column in columnReaders. It
+// returns nil if any of the columns does not have a row index with an entry
+// for each row group whose positions can be used to seek its readers. Files
+// written by earlier versions of this package recorded a single position for
+// each stream, so entries without the expected number of positions are not
+// used.
+func (c *Cursor) loadRowIndexes() (map[int]*proto.RowIndex, error) {
+	numRowGroups := c.numRowGroups()
+	if numRowGroups == 0 {
+		return nil, nil
+	}
+	types, err := c.Reader.getTypes()
+	if err != nil {
+		return nil, err
+	}
+	compressed := c.Reader.postScript.GetCompression() != proto.CompressionKind_NONE
+	rowIndexes := make(map[int]*proto.RowIndex)
+	for id := range c.columnReaders {
+		if _, ok := c.Stripe.streamMap[streamName{id, proto.Stream_ROW_INDEX}]; !ok {
+			return nil, nil
+		}
+		rowIndex, err := c.Stripe.getRowIndex(id)
+		if err != nil {
+			return nil, err
+		}
+		entries := rowIndex.GetEntry()
+		if len(entries) < numRowGroups || id >= len(types) {
+			return nil, nil
+		}
+		encoding, err := c.Stripe.getColumn(id)
+		if err != nil {
+			return nil, err
+		}
+		_, hasPresent := c.Stripe.streamMap[streamName{id, proto.Stream_PRESENT}]
+		n := numPositions(types[id].GetKind(), encoding.GetKind(), hasPresent, compressed)
+		for _, entry := range entries[:numRowGroups] {
+			if len(entry.GetPositions()) != n {
+				return nil, nil
+			}
+		}
+		rowIndexes[id] = rowIndex
+	}
+	return rowIndexes, nil
+}
+
+// selectRowGroups evaluates the predicate against the statistics of each row
+// group of the current stripe. Row groups are only skipped if every selected
+// column has a row index whose positions can be used to seek its readers.
+func (c *Cursor) selectRowGroups() error {
+	c.rowGroups = nil
+	c.rowIndexes = nil
+	if c.predicate == nil {
+		return nil
+	}
+	rowIndexes, err := c.loadRowIndexes()
+	if err != nil || rowIndexes == nil {
+		return err
+	}
+	predicateIndexes := make(map[int]*proto.RowIndex)
+	for _, id := range c.indexed() {
+		if rowIndex, ok := rowIndexes[id]; ok {
+			predicateIndexes[id] = rowIndex
+			continue
+		}
+		if _, ok := c.Stripe.streamMap[streamName{id, proto.Stream_ROW_INDEX}]; !ok {
+			continue
+		}
+		rowIndex, err := c.Stripe.getRowIndex(id)
+		if err != nil {
+			return err
+		}
+		predicateIndexes[id] = rowIndex
+	}
+	rowGroups := make([]bool, c.numRowGroups())
+	var skip bool
+	for i := range rowGroups {
+		rowGroups[i] = c.predicate.mightMatch(func(column string) (*proto.ColumnStatistics, *TypeDescription, bool) {
+			td, ok, err := c.predicateColumn(column)
+			if err != nil || !ok {
+				return nil, nil, false
+			}
+			entries := predicateIndexes[td.getID()].GetEntry()
+			if i >= len(entries) {
+				return nil, nil, false
+			}
+			return entries[i].GetStatistics(), td, true
+		})
+		skip = skip || !rowGroups[i]
+	}
+	if skip {
+		c.rowGroups = rowGroups
+		c.rowIndexes = rowIndexes
+	}
+	return nil
+}
+
+// skipRowGroups seeks the readers past any row groups, starting at the current
+// row, that cannot satisfy the predicate. It returns false if no row groups of
+// the current stripe remain.
+func (c *Cursor) skipRowGroups() bool {
+	stride := int(c.Reader.footer.GetRowIndexStride())
+	if c.currentRow%stride != 0 {
+		return true
+	}
+	rowGroup := c.currentRow / stride
+	next := rowGroup
+	for next < len(c.rowGroups) && !c.rowGroups[next] {
+		next++
+	}
+	if next == rowGroup {
+		return true
+	}
+	if next == len(c.rowGroups) {
+		c.currentRow = int(c.Stripe.GetNumberOfRows())
+		return false
+	}
+	if err := c.seekRowGroup(next); err != nil {
+		c.err = err
+		return false
+	}
+	c.currentRow = next * stride
+	return true
+}
+
+// seekRowGroup seeks the readers of each column to the start of the row group
+// using the positions recorded in its row index.
+func (c *Cursor) seekRowGroup(rowGroup int) error {
+	for id, reader := range c.columnReaders {
+		entry := c.rowIndexes[id].GetEntry()[rowGroup]
+		p := newPositionProvider(entry.GetPositions())
+		if err := seekReader(reader, p); err != nil {
+			return fmt.Errorf("unable to seek column %v to row group %v: %v", id, rowGroup, err)
+		}
+		if len(p.positions) != 0 {
+			return fmt.Errorf("unable to seek column %v to row group %v: row index entry has too many positions", id, rowGroup)
+		}
+	}
 	return nil
 }
 
@@ -76,7 +435,11 @@ func (c *Cursor) prepareNextStripe() error {
 	// and creating the required readers for each of the
 	// required columns.
 	var err error
-	stripe, err := c.Reader.getStripe(c.stripeOffset, c.included...)
+	// Skip any stripes that cannot satisfy the predicate.
+	for !c.stripeMightMatch(c.stripeOffset) {
+		c.stripeOffset++
+	}
+	stripe, err := c.Reader.getStripe(c.stripeOffset, c.included, c.indexed())
 	if err != nil {
 		return err
 	}
@@ -101,10 +464,17 @@ func (c *Cursor) next() bool {
 	if c.err != nil {
 		return false
 	}
+	if c.decoded != nil {
+		return c.nextDecoded()
+	}
 	// If there are no readers then return false.
 	if len(c.readers) == 0 {
 		return false
 	}
+	// Skip any row groups that cannot satisfy the predicate.
+	if c.rowGroups != nil && !c.skipRowGroups() {
+		return false
+	}
 	if c.currentRow >= int(c.Stripe.GetNumberOfRows()) {
 		return false
 	}
@@ -120,8 +490,61 @@ func (c *Cursor) next() bool {
 	return hasNext
 }
 
+// nextDecoded moves to the next row decoded by the pipeline, receiving the next
+// batch once the rows of the current batch are exhausted.
+func (c *Cursor) nextDecoded() bool {
+	c.batchRow++
+	for c.batch == nil || c.batchRow >= c.batch.Len {
+		if !c.nextDecodedBatch() {
+			return false
+		}
+		c.batchRow = 0
+	}
+	c.currentRow++
+	return true
+}
+
+// nextDecodedBatch returns the current batch to the pipeline for reuse and
+// receives the next batch of rows decoded by it, positioned before its first
+// row. It returns false once the rows of the stripe are exhausted.
+func (c *Cursor) nextDecodedBatch() bool {
+	if c.batch != nil {
+		select {
+		case c.decoded.free <- c.batch:
+		default:
+		}
+		c.batch = nil
+	}
+	b, ok := <-c.decoded.batches
+	if !ok {
+		if err := c.decoded.decodeErr; err != nil {
+			c.err = err
+		}
+		return false
+	}
+	c.batch = b
+	c.batchRow = -1
+	return true
+}
+
+// releaseDecoded stops the decoding of the current stripe by the pipeline.
+func (c *Cursor) releaseDecoded() {
+	if c.decoded != nil {
+		c.decoded.release()
+		c.decoded = nil
+		c.batch = nil
+	}
+}
+
 // row preallocates the next row of values and stores in nextVal.
 func (c *Cursor) row() {
+	if c.decoded != nil {
+		c.nextVal = make([]interface{}, len(c.columns))
+		for i, column := range c.columns {
+			c.nextVal[i] = vectorValue(column, c.batch.Columns[i], c.batchRow)
+		}
+		return
+	}
 	c.nextVal = make([]interface{}, len(c.readers), len(c.readers))
 	for i, reader := range c.readers {
 		c.nextVal[i] = reader.Value()
@@ -135,8 +558,8 @@ func (c *Cursor) Row() []interface{} {
 
 // Scan assigns the values returned by the readers to the destination slice.
 func (c *Cursor) Scan(dest ...interface{}) error {
-	if len(dest) != len(c.readers) {
-		return fmt.Errorf("expected destination slice of length %v got %v", len(c.readers), len(dest))
+	if len(dest) != len(c.columns) {
+		return fmt.Errorf("expected destination slice of length %v got %v", len(c.columns), len(dest))
 	}
 	for i, v := range c.nextVal {
 		dest[i] = v
@@ -165,6 +588,12 @@ func (c *Cursor) Err() error {
 // Stripes prepares the next stripe for reading, returning true once its ready. It
 // returns false if an error occurs whilst preparing the stripe.
 func (c *Cursor) Stripes() bool {
+	if c.err != nil {
+		return false
+	}
+	if c.Reader.concurrency > 0 {
+		return c.nextPreparedStripe()
+	}
 	// Prepare the next stripe for reading.
 	err := c.prepareNextStripe()
 	if err != nil {
@@ -175,24 +604,110 @@ func (c *Cursor) Stripes() bool {
 	return true
 }
 
-// RowIndex returns the row index for the provided column from the current strip
-func (c *Cursor) RowIndex(column string) (*proto.RowIndex, error) {
+// nextPreparedStripe makes the next stripe decoded by the pipeline the current
+// stripe, starting the pipeline if required.
+func (c *Cursor) nextPreparedStripe() bool {
+	if c.pipeline == nil {
+		stripes, err := c.Reader.getStripes()
+		if err != nil {
+			c.err = err
+			return false
+		}
+		template := &Cursor{
+			Reader:    c.Reader,
+			columns:   c.columns,
+			included:  c.included,
+			predicate: c.predicate,
+			evolution: c.evolution,
+		}
+		c.pipeline = newStripePipeline(template, c.stripeOffset, len(stripes), c.Reader.concurrency, !c.Reader.unordered)
+	}
+	c.releaseDecoded()
+	prepared, ok := c.pipeline.next()
+	if !ok {
+		c.closePipeline()
+		c.err = io.EOF
+		return false
+	}
+	if prepared.err != nil {
+		c.closePipeline()
+		c.err = prepared.err
+		return false
+	}
+	c.Stripe = prepared.stripe
+	c.decoded = prepared
+	c.readers = nil
+	c.columnReaders = nil
+	c.rowIndexes = nil
+	c.rowGroups = nil
+	c.stripeOffset = prepared.num + 1
+	c.currentRow = 0
+	return true
+}
+
+// closePipeline stops any goroutines decoding stripes ahead of the Cursor.
+func (c *Cursor) closePipeline() {
+	c.releaseDecoded()
+	if c.pipeline != nil {
+		c.pipeline.close()
+		c.pipeline = nil
+	}
+}
+
+// Close stops any goroutines decoding stripes ahead of the Cursor, it should be
+// called if the Reader has concurrency enabled and the Cursor is not read until
+// Stripes returns false.
+func (c *Cursor) Close() error {
+	c.closePipeline()
+	return nil
+}
+
+// BloomFilter returns the bloom filters for each row group of the provided column
+// from the current stripe. The column must be selected by the Cursor and have
+// been written with bloom filters, otherwise an error is returned.
+func (c *Cursor) BloomFilter(column string) ([]*BloomFilter, error) {
 	col, err := c.Reader.schema.GetField(column)
 	if err != nil {
 		return nil, err
 	}
 	stream := c.Stripe.get(streamName{
 		columnID: col.getID(),
-		kind:     proto.Stream_ROW_INDEX,
+		kind:     proto.Stream_BLOOM_FILTER_UTF8,
 	})
-	var rowIndex proto.RowIndex
+	if stream == nil {
+		stream = c.Stripe.get(streamName{
+			columnID: col.getID(),
+			kind:     proto.Stream_BLOOM_FILTER,
+		})
+	}
+	if stream == nil {
+		return nil, fmt.Errorf("no bloom filter for column: %s", column)
+	}
+	var bloomFilterIndex proto.BloomFilterIndex
 	byt, err := ioutil.ReadAll(stream)
 	if err != nil {
 		return nil, err
 	}
-	err = gproto.Unmarshal(byt, &rowIndex)
+	err = gproto.Unmarshal(byt, &bloomFilterIndex)
+	if err != nil {
+		return nil, err
+	}
+	writerVersion := c.Reader.postScript.GetWriterVersion()
+	bloomFilters := make([]*BloomFilter, len(bloomFilterIndex.BloomFilter))
+	for i, bloomFilter := range bloomFilterIndex.BloomFilter {
+		bloomFilters[i], err = newBloomFilterFromProto(col.category, bloomFilter, writerVersion)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return bloomFilters, nil
+}
+
+// RowIndex returns the row index for the provided column from the current stripe.
+func (c *Cursor) RowIndex(column string) (*proto.RowIndex, error) {
+	col, err := c.Reader.schema.GetField(column)
 	if err != nil {
 		return nil, err
 	}
-	return &rowIndex, nil
+	return c.Stripe.getRowIndex(col.getID())
 }
@@ -1,6 +1,9 @@
 package orc
 
 import (
+	"bytes"
+	"fmt"
+	"math/rand"
 	"reflect"
 	"testing"
 
@@ -175,3 +178,245 @@ func TestCursorSelectStripe(t *testing.T) {
 		}
 	}
 }
+
+func TestCursorSeekRow(t *testing.T) {
+	r, err := Open("./examples/TestOrcFile.testSeek.orc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	cols := r.Schema().Columns()
+
+	// Read every row sequentially to compare against.
+	var rows [][]interface{}
+	c := r.Select(cols...)
+	for c.Stripes() {
+		for c.Next() {
+			rows = append(rows, c.Row())
+		}
+	}
+	if err := c.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Seek forwards and backwards, within and across stripes and row groups.
+	c = r.Select(cols...)
+	for _, n := range []int{0, 545, 546, 999, 1000, 20000, 7000, len(rows) - 1, 1} {
+		err := c.SeekRow(int64(n))
+		if err != nil {
+			t.Fatalf("Test failed seeking to row %v: %v", n, err)
+		}
+		if !c.Next() {
+			t.Fatalf("Test failed seeking to row %v, expected true, got false: %v", n, c.Err())
+		}
+		if !reflect.DeepEqual(c.Row(), rows[n]) {
+			t.Errorf("Test failed seeking to row %v, expected %v, got %v", n, rows[n], c.Row())
+		}
+	}
+
+	// Reading continues through the following stripes after a seek.
+	start := len(rows) / 2
+	if err := c.SeekRow(int64(start)); err != nil {
+		t.Fatal(err)
+	}
+	i := start
+	for {
+		for c.Next() {
+			if !reflect.DeepEqual(c.Row(), rows[i]) {
+				t.Fatalf("Test failed for row %v, expected %v, got %v", i, rows[i], c.Row())
+			}
+			i++
+		}
+		if !c.Stripes() {
+			break
+		}
+	}
+	if err := c.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if i != len(rows) {
+		t.Errorf("Test failed, expected %v rows, got %v", len(rows), i)
+	}
+
+	for _, n := range []int64{-1, int64(len(rows))} {
+		if err := c.SeekRow(n); err == nil {
+			t.Errorf("Test failed, expected an error seeking to row %v", n)
+		}
+	}
+
+	// Rows can be sought after every stripe has been read.
+	for _, concurrency := range []int{0, 2} {
+		r, err := Open("./examples/TestOrcFile.testSeek.orc", SetConcurrency(concurrency))
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := r.Select(cols...)
+		for c.Stripes() {
+			for c.Next() {
+			}
+		}
+		for _, n := range []int{7000, 0} {
+			if err := c.SeekRow(int64(n)); err != nil {
+				t.Fatalf("Test failed seeking to row %v with concurrency %v: %v", n, concurrency, err)
+			}
+			i := n
+			for {
+				for c.Next() {
+					if !reflect.DeepEqual(c.Row(), rows[i]) {
+						t.Fatalf("Test failed for row %v with concurrency %v, expected %v, got %v", i, concurrency, rows[i], c.Row())
+					}
+					i++
+				}
+				if !c.Stripes() {
+					break
+				}
+			}
+			if err := c.Err(); err != nil {
+				t.Fatal(err)
+			}
+			if i != len(rows) {
+				t.Errorf("Test failed with concurrency %v, expected %v rows, got %v", concurrency, len(rows), i)
+			}
+		}
+		r.Close()
+	}
+}
+
+func TestCursorConcurrency(t *testing.T) {
+	r, err := Open("./examples/TestOrcFile.testSeek.orc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	cols := r.Schema().Columns()
+
+	readRows := func(r *Reader) [][]interface{} {
+		var rows [][]interface{}
+		c := r.Select(cols...)
+		for c.Stripes() {
+			for c.Next() {
+				rows = append(rows, c.Row())
+			}
+		}
+		if err := c.Err(); err != nil {
+			t.Fatal(err)
+		}
+		return rows
+	}
+
+	expected := readRows(r)
+
+	ordered, err := Open("./examples/TestOrcFile.testSeek.orc", SetConcurrency(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ordered.Close()
+
+	rows := readRows(ordered)
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("Test failed, expected rows to be returned in file order")
+	}
+
+	unordered, err := Open("./examples/TestOrcFile.testSeek.orc", SetConcurrency(4), SetOrdered(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unordered.Close()
+
+	// The same rows are returned, in any order.
+	counts := make(map[string]int)
+	for _, row := range expected {
+		counts[fmt.Sprint(row)]++
+	}
+	for _, row := range readRows(unordered) {
+		counts[fmt.Sprint(row)]--
+	}
+	for row, count := range counts {
+		if count != 0 {
+			t.Fatalf("Test failed, expected row %v to be returned once", row)
+		}
+	}
+
+	// A Cursor can be closed before it has been read to the end.
+	c := ordered.Select(cols...)
+	if !c.Stripes() || !c.Next() {
+		t.Fatalf("Test failed, expected a row: %v", c.Err())
+	}
+	if !reflect.DeepEqual(c.Row(), expected[0]) {
+		t.Errorf("Test failed, expected %v, got %v", expected[0], c.Row())
+	}
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Stripes moves to the next stripe before the rows of the current stripe
+	// have been consumed.
+	single, err := Open("./examples/TestOrcFile.testSeek.orc", SetConcurrency(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer single.Close()
+	c = single.Select(cols...)
+	var stripes int
+	for c.Stripes() {
+		if !c.Next() {
+			t.Fatalf("Test failed, expected a row: %v", c.Err())
+		}
+		stripes++
+	}
+	if err := c.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := single.NumStripes(); err != nil || stripes != n {
+		t.Errorf("Test failed, expected %v stripes got %v: %v", n, stripes, err)
+	}
+
+	if _, err := Open("./examples/TestOrcFile.testSeek.orc", SetConcurrency(-1)); err == nil {
+		t.Errorf("Test failed, expected an error for an invalid concurrency")
+	}
+}
+
+func BenchmarkCursorConcurrency(b *testing.B) {
+	schema, err := ParseSchema("struct<int1:bigint,string1:string,double1:double,list1:array<int>>")
+	if err != nil {
+		b.Fatal(err)
+	}
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, SetSchema(schema), SetCompression(CompressionZlib{}), SetStripeTargetSize(1<<20))
+	if err != nil {
+		b.Fatal(err)
+	}
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 500000; i++ {
+		err := w.Write(rnd.Int63(), fmt.Sprint(rnd.Int63n(100000)), rnd.Float64(), []int64{int64(i), rnd.Int63n(1000)})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		b.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	for _, concurrency := range []int{0, 1, 2, 4} {
+		b.Run(fmt.Sprintf("concurrency=%v", concurrency), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				r, err := NewReader(&bytesSizedReaderAt{bytes.NewBuffer(data)}, SetConcurrency(concurrency))
+				if err != nil {
+					b.Fatal(err)
+				}
+				c := r.Select(schema.Columns()...)
+				batch := NewBatch(DefaultBatchSize)
+				for c.Stripes() {
+					for c.NextBatch(batch) {
+					}
+				}
+				if err := c.Err(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
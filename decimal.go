@@ -2,7 +2,6 @@ package orc
 
 import (
 	"io"
-	"math"
 	"math/big"
 )
 
@@ -44,15 +43,101 @@ func (d Decimal) MarshalJSON() ([]byte, error) {
 	return []byte(d.String()), nil
 }
 
+// Rat returns the decimal value as a big.Rat.
+func (d Decimal) Rat() *big.Rat {
+	var f big.Rat
+	return f.SetFrac(d.Int, scaleToDenominator(d.Scale))
+}
+
+// Rescale returns the decimal value adjusted to the provided scale. If the
+// scale is reduced then the value is rounded half away from zero.
+func (d Decimal) Rescale(scale int64) Decimal {
+	switch {
+	case scale == d.Scale:
+		return d
+	case scale > d.Scale:
+		var mant big.Int
+		mant.Mul(d.Int, scaleToDenominator(scale-d.Scale))
+		return NewDecimal(&mant, scale)
+	default:
+		return NewDecimal(quoRound(d.Int, scaleToDenominator(d.Scale-scale)), scale)
+	}
+}
+
+// Precision returns the number of digits in the unscaled value.
+func (d Decimal) Precision() int {
+	var abs big.Int
+	abs.Abs(d.Int)
+	if abs.Sign() == 0 {
+		return 1
+	}
+	return len(abs.String())
+}
+
+// decimalFromRat returns a Decimal with the provided scale that is
+// the closest value to r, rounding half away from zero.
+func decimalFromRat(r *big.Rat, scale int64) Decimal {
+	var num big.Int
+	num.Mul(r.Num(), scaleToDenominator(scale))
+	return NewDecimal(quoRound(&num, r.Denom()), scale)
+}
+
+// quoRound returns x/y rounded half away from zero.
+func quoRound(x, y *big.Int) *big.Int {
+	var q, r big.Int
+	q.QuoRem(x, y, &r)
+	r.Abs(&r)
+	r.Lsh(&r, 1)
+	var absY big.Int
+	absY.Abs(y)
+	if r.Cmp(&absY) >= 0 {
+		if x.Sign()*y.Sign() < 0 {
+			q.Sub(&q, big.NewInt(1))
+		} else {
+			q.Add(&q, big.NewInt(1))
+		}
+	}
+	return &q
+}
+
 func scaleToDenominator(i int64) *big.Int {
-	return big.NewInt(int64((1 / math.Pow(10, -float64(i)))))
+	var d big.Int
+	return d.Exp(big.NewInt(10), big.NewInt(i), nil)
+}
+
+// encodeBase128Varint encodes i as an unbounded Base128 varint
+// and writes it to w, returning an error if one occurs.
+func encodeBase128Varint(w io.ByteWriter, i *big.Int) error {
+	value := zigzagEncodeBigInt(i)
+	mask := big.NewInt(0x7f)
+	var b big.Int
+	for {
+		b.And(value, mask)
+		value.Rsh(value, 7)
+		if value.Sign() == 0 {
+			return w.WriteByte(byte(b.Uint64()))
+		}
+		if err := w.WriteByte(byte(b.Uint64()) | 0x80); err != nil {
+			return err
+		}
+	}
+}
+
+func zigzagEncodeBigInt(i *big.Int) *big.Int {
+	var result big.Int
+	result.Lsh(i, 1)
+	if i.Sign() < 0 {
+		result.Neg(&result)
+		result.Sub(&result, big.NewInt(1))
+	}
+	return &result
 }
 
 // decodeBase128Varint decodes an unbounded Base128 varint
 // from r, returning a big.Int or an error.
 func decodeBase128Varint(r io.ByteReader) (*big.Int, error) {
 	var result big.Int
-	var offset int
+	var offset uint
 	b := int64(0x80)
 	for (b & 0x80) != 0 {
 		nb, err := r.ReadByte()
@@ -63,7 +148,9 @@ func decodeBase128Varint(r io.ByteReader) (*big.Int, error) {
 		if b == -1 {
 			return nil, ErrEOFUnsignedVInt
 		}
-		result.Or(&result, big.NewInt((b&0x7f)<<uint64(offset)))
+		var chunk big.Int
+		chunk.Lsh(big.NewInt(b&0x7f), offset)
+		result.Or(&result, &chunk)
 		offset += 7
 	}
 	return zigzagDecodeBigInt(&result), nil
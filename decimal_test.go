@@ -29,3 +29,63 @@ func TestDecimal(t *testing.T) {
 	}
 
 }
+
+func TestDecimalRescale(t *testing.T) {
+
+	testCases := []struct {
+		value    Decimal
+		scale    int64
+		expected string
+	}{
+		{Decimal{big.NewInt(12345), 2}, 4, "123.4500"},
+		{Decimal{big.NewInt(12345), 2}, 1, "123.5"},
+		{Decimal{big.NewInt(-12345), 2}, 1, "-123.5"},
+		{Decimal{big.NewInt(12344), 2}, 1, "123.4"},
+		{Decimal{big.NewInt(-12344), 3}, 0, "-12"},
+		{Decimal{big.NewInt(5), 0}, 0, "5"},
+	}
+
+	for _, tc := range testCases {
+		actual := tc.value.Rescale(tc.scale)
+		if actual.Scale != tc.scale {
+			t.Errorf("Test failed, expected scale %v got %v", tc.scale, actual.Scale)
+		}
+		if actual.String() != tc.expected {
+			t.Errorf("Test failed, expected %s got %s", tc.expected, actual.String())
+		}
+	}
+
+}
+
+func TestBase128Varint(t *testing.T) {
+
+	large, _ := new(big.Int).SetString("-99999999999999999999999999999999999999", 10)
+	values := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(-1),
+		big.NewInt(63),
+		big.NewInt(-64),
+		big.NewInt(1 << 40),
+		large,
+	}
+
+	var buf bytes.Buffer
+	for _, v := range values {
+		err := encodeBase128Varint(&buf, v)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, v := range values {
+		actual, err := decodeBase128Varint(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual.Cmp(v) != 0 {
+			t.Errorf("Test failed, expected %v got %v", v, actual)
+		}
+	}
+
+}
@@ -0,0 +1,11 @@
+module github.com/scritchley/orc
+
+go 1.22
+
+require (
+	github.com/golang/protobuf v1.5.4
+	github.com/golang/snappy v1.0.0
+	github.com/klauspost/compress v1.18.0
+)
+
+require google.golang.org/protobuf v1.33.0 // indirect
@@ -0,0 +1,153 @@
+package orc
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// ORC uses the raw LZ4 block format, without the LZ4 frame format headers,
+// to compress each chunk of a stream.
+
+const (
+	lz4MinMatch       = 4
+	lz4LastLiterals   = 5
+	lz4MatchFindLimit = 12
+	lz4MaxOffset      = 1<<16 - 1
+	lz4HashLog        = 16
+)
+
+var (
+	errLZ4Corrupt = errors.New("lz4: corrupt input")
+)
+
+// lz4Decode decodes the LZ4 block src and appends the result to dst,
+// returning the extended slice or an error if src is malformed.
+func lz4Decode(dst, src []byte) ([]byte, error) {
+	base := len(dst)
+	i := 0
+	for i < len(src) {
+		token := src[i]
+		i++
+		// Copy the literals.
+		literals, n, err := lz4ReadLength(src[i:], int(token>>4))
+		if err != nil {
+			return nil, err
+		}
+		i += n
+		if i+literals > len(src) {
+			return nil, errLZ4Corrupt
+		}
+		dst = append(dst, src[i:i+literals]...)
+		i += literals
+		// The last sequence contains only literals.
+		if i == len(src) {
+			break
+		}
+		if i+2 > len(src) {
+			return nil, errLZ4Corrupt
+		}
+		offset := int(binary.LittleEndian.Uint16(src[i:]))
+		i += 2
+		if offset == 0 || offset > len(dst)-base {
+			return nil, errLZ4Corrupt
+		}
+		matchLength, n, err := lz4ReadLength(src[i:], int(token&0x0f))
+		if err != nil {
+			return nil, err
+		}
+		i += n
+		matchLength += lz4MinMatch
+		// Copy the match one byte at a time as it may overlap the output.
+		start := len(dst) - offset
+		for j := 0; j < matchLength; j++ {
+			dst = append(dst, dst[start+j])
+		}
+	}
+	return dst, nil
+}
+
+// lz4ReadLength reads the optional length extension bytes that follow a token nibble.
+func lz4ReadLength(src []byte, length int) (int, int, error) {
+	if length != 0x0f {
+		return length, 0, nil
+	}
+	for i := range src {
+		length += int(src[i])
+		if src[i] != 0xff {
+			return length, i + 1, nil
+		}
+	}
+	return 0, 0, errLZ4Corrupt
+}
+
+// lz4Encode compresses src as an LZ4 block and appends the result to dst.
+func lz4Encode(dst, src []byte) []byte {
+	var table [1 << lz4HashLog]int32
+	anchor := 0
+	matchLimit := len(src) - lz4LastLiterals
+	for i := 0; i+lz4MatchFindLimit < len(src); {
+		sequence := binary.LittleEndian.Uint32(src[i:])
+		h := (sequence * 2654435761) >> (32 - lz4HashLog)
+		// Positions are stored offset by one so that zero means no entry.
+		ref := int(table[h]) - 1
+		table[h] = int32(i + 1)
+		if ref < 0 || i-ref > lz4MaxOffset || binary.LittleEndian.Uint32(src[ref:]) != sequence {
+			i++
+			continue
+		}
+		// Extend the match backwards over any pending literals.
+		for i > anchor && ref > 0 && src[i-1] == src[ref-1] {
+			i--
+			ref--
+		}
+		// Extend the match forwards up to the limit.
+		length := lz4MinMatch
+		for i+length < matchLimit && src[i+length] == src[ref+length] {
+			length++
+		}
+		dst = lz4AppendSequence(dst, src[anchor:i], i-ref, length)
+		i += length
+		anchor = i
+	}
+	return lz4AppendSequence(dst, src[anchor:], 0, 0)
+}
+
+// lz4AppendSequence appends a sequence of literals followed by a match to dst. A
+// matchLength of zero indicates the final sequence, which contains only literals.
+func lz4AppendSequence(dst, literals []byte, offset, matchLength int) []byte {
+	var token byte
+	if len(literals) >= 0x0f {
+		token = 0xf0
+	} else {
+		token = byte(len(literals)) << 4
+	}
+	if matchLength != 0 {
+		if matchLength-lz4MinMatch >= 0x0f {
+			token |= 0x0f
+		} else {
+			token |= byte(matchLength - lz4MinMatch)
+		}
+	}
+	dst = append(dst, token)
+	if len(literals) >= 0x0f {
+		dst = lz4AppendLength(dst, len(literals)-0x0f)
+	}
+	dst = append(dst, literals...)
+	if matchLength == 0 {
+		return dst
+	}
+	dst = append(dst, byte(offset), byte(offset>>8))
+	if matchLength-lz4MinMatch >= 0x0f {
+		dst = lz4AppendLength(dst, matchLength-lz4MinMatch-0x0f)
+	}
+	return dst
+}
+
+// lz4AppendLength appends the length extension bytes for length to dst.
+func lz4AppendLength(dst []byte, length int) []byte {
+	for length >= 0xff {
+		dst = append(dst, 0xff)
+		length -= 0xff
+	}
+	return append(dst, byte(length))
+}
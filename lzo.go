@@ -0,0 +1,157 @@
+package orc
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// ORC uses the raw LZO1X block format, without any framing, to compress each
+// chunk of a stream. Only decompression is supported.
+
+const (
+	lzoM2MaxOffset = 0x0800
+	lzoM3MaxOffset = 0x4000
+)
+
+var (
+	errLZOCorrupt = errors.New("lzo: corrupt input")
+)
+
+// lzoDecode decodes the LZO1X block src and appends the result to dst,
+// returning the extended slice or an error if src is malformed.
+func lzoDecode(dst, src []byte) ([]byte, error) {
+	base := len(dst)
+	ip := 0
+	// state holds the number of literals copied after the previous
+	// instruction, or 4 following a literal run of four or more bytes.
+	state := 0
+	if len(src) > 0 && src[0] > 17 {
+		t := int(src[0]) - 17
+		ip++
+		if ip+t > len(src) {
+			return nil, errLZOCorrupt
+		}
+		dst = append(dst, src[ip:ip+t]...)
+		ip += t
+		state = t
+		if state > 4 {
+			state = 4
+		}
+	}
+	for ip < len(src) {
+		t := int(src[ip])
+		ip++
+		var distance, length, literals int
+		var err error
+		switch {
+		case t < 16 && state == 0:
+			// A run of literals.
+			length = t
+			if length == 0 {
+				length, ip, err = lzoReadLength(src, ip, 15)
+				if err != nil {
+					return nil, err
+				}
+			}
+			length += 3
+			if ip+length > len(src) {
+				return nil, errLZOCorrupt
+			}
+			dst = append(dst, src[ip:ip+length]...)
+			ip += length
+			state = 4
+			continue
+		case t < 16:
+			// A short match whose form depends on the preceding literals.
+			if ip >= len(src) {
+				return nil, errLZOCorrupt
+			}
+			distance = 1 + t>>2 + int(src[ip])<<2
+			ip++
+			length = 2
+			if state == 4 {
+				distance += lzoM2MaxOffset
+				length = 3
+			}
+			literals = t & 3
+		case t >= 64:
+			// A match within lzoM2MaxOffset bytes.
+			if ip >= len(src) {
+				return nil, errLZOCorrupt
+			}
+			distance = 1 + (t>>2)&7 + int(src[ip])<<3
+			ip++
+			length = t>>5 + 1
+			literals = t & 3
+		case t >= 32:
+			// A match within lzoM3MaxOffset bytes.
+			length = t & 31
+			if length == 0 {
+				length, ip, err = lzoReadLength(src, ip, 31)
+				if err != nil {
+					return nil, err
+				}
+			}
+			length += 2
+			if ip+2 > len(src) {
+				return nil, errLZOCorrupt
+			}
+			v := int(binary.LittleEndian.Uint16(src[ip:]))
+			ip += 2
+			distance = 1 + v>>2
+			literals = v & 3
+		default:
+			// A match beyond lzoM3MaxOffset bytes, or the end of stream marker.
+			length = t & 7
+			if length == 0 {
+				length, ip, err = lzoReadLength(src, ip, 7)
+				if err != nil {
+					return nil, err
+				}
+			}
+			length += 2
+			if ip+2 > len(src) {
+				return nil, errLZOCorrupt
+			}
+			v := int(binary.LittleEndian.Uint16(src[ip:]))
+			ip += 2
+			distance = (t&8)<<11 + v>>2
+			if distance == 0 {
+				return dst, nil
+			}
+			distance += lzoM3MaxOffset
+			literals = v & 3
+		}
+		if distance > len(dst)-base {
+			return nil, errLZOCorrupt
+		}
+		// Copy the match one byte at a time as it may overlap the output.
+		start := len(dst) - distance
+		for j := 0; j < length; j++ {
+			dst = append(dst, dst[start+j])
+		}
+		if ip+literals > len(src) {
+			return nil, errLZOCorrupt
+		}
+		dst = append(dst, src[ip:ip+literals]...)
+		ip += literals
+		state = literals
+	}
+	return dst, nil
+}
+
+// lzoReadLength reads an extended length starting at src[ip], where each zero
+// byte adds 255 and the first non-zero byte terminates the length. It returns
+// the length added to base and the position following the length.
+func lzoReadLength(src []byte, ip int, base int) (int, int, error) {
+	length := base
+	for ip < len(src) && src[ip] == 0 {
+		length += 255
+		ip++
+	}
+	if ip >= len(src) {
+		return 0, 0, errLZOCorrupt
+	}
+	length += int(src[ip])
+	return length, ip + 1, nil
+}
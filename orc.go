@@ -32,10 +32,10 @@ func (f fileReader) Size() int64 {
 }
 
 // Open opens the file at the provided filepath.
-func Open(filepath string) (*Reader, error) {
+func Open(filepath string, fns ...ReaderConfigFunc) (*Reader, error) {
 	f, err := os.Open(filepath)
 	if err != nil {
 		return nil, err
 	}
-	return NewReader(fileReader{f})
+	return NewReader(fileReader{f}, fns...)
 }
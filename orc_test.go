@@ -61,6 +61,14 @@ func TestReadExamples(t *testing.T) {
 			expected: "TestOrcFile.columnProjection.jsn.gz",
 			example:  "TestOrcFile.columnProjection.orc",
 		},
+		{
+			expected: "TestVectorOrcFile.testLz4.jsn.gz",
+			example:  "TestVectorOrcFile.testLz4.orc",
+		},
+		{
+			expected: "TestVectorOrcFile.testLzo.jsn.gz",
+			example:  "TestVectorOrcFile.testLzo.orc",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,86 @@
+package orc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/scritchley/orc/proto"
+)
+
+var (
+	errPositionsExhausted = errors.New("row index entry has too few positions")
+)
+
+// positionProvider provides the positions of a row index entry in the order
+// that they are consumed by the streams of a column.
+type positionProvider struct {
+	positions []uint64
+}
+
+func newPositionProvider(positions []uint64) *positionProvider {
+	return &positionProvider{positions: positions}
+}
+
+// next returns the next position, or an error if none remain.
+func (p *positionProvider) next() (uint64, error) {
+	if len(p.positions) == 0 {
+		return 0, errPositionsExhausted
+	}
+	position := p.positions[0]
+	p.positions = p.positions[1:]
+	return position, nil
+}
+
+// seeker is implemented by streams, run length encoded readers and TreeReaders
+// that can seek to the positions recorded in the row index.
+type seeker interface {
+	seek(p *positionProvider) error
+}
+
+// seekReader seeks r to the next positions provided by p, returning an error
+// if r does not implement seeker.
+func seekReader(r interface{}, p *positionProvider) error {
+	s, ok := r.(seeker)
+	if !ok {
+		return fmt.Errorf("unable to seek reader of type %T", r)
+	}
+	return s.seek(p)
+}
+
+// numPositions returns the number of positions in each row index entry of a
+// column of the provided kind, which are consumed by seeking its TreeReader.
+// Each stream records its offset, preceded by the offset of its chunk if it is
+// compressed, followed by the number of values to skip if it is run length
+// encoded and then the number of bits to skip if it holds booleans.
+func numPositions(kind proto.Type_Kind, encoding proto.ColumnEncoding_Kind, hasPresent, compressed bool) int {
+	stream := 1
+	if compressed {
+		stream = 2
+	}
+	runLength := stream + 1
+	var n int
+	if hasPresent {
+		n = runLength + 1
+	}
+	switch kind {
+	case proto.Type_BOOLEAN:
+		return n + runLength + 1
+	case proto.Type_BYTE, proto.Type_SHORT, proto.Type_INT, proto.Type_LONG, proto.Type_DATE:
+		return n + runLength
+	case proto.Type_FLOAT, proto.Type_DOUBLE:
+		return n + stream
+	case proto.Type_STRING, proto.Type_VARCHAR, proto.Type_CHAR:
+		if encoding == proto.ColumnEncoding_DICTIONARY || encoding == proto.ColumnEncoding_DICTIONARY_V2 {
+			return n + runLength
+		}
+		return n + stream + runLength
+	case proto.Type_BINARY, proto.Type_DECIMAL:
+		return n + stream + runLength
+	case proto.Type_TIMESTAMP, proto.Type_TIMESTAMP_INSTANT:
+		return n + 2*runLength
+	case proto.Type_LIST, proto.Type_MAP, proto.Type_UNION:
+		return n + runLength
+	default:
+		return n
+	}
+}
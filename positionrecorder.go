@@ -1,5 +1,7 @@
 package orc
 
+import "io"
+
 type PositionRecorders []PositionRecorder
 
 type PositionRecorder interface {
@@ -9,3 +11,12 @@ type PositionRecorder interface {
 func NewPositionRecorders(recorders ...PositionRecorder) PositionRecorders {
 	return PositionRecorders(recorders)
 }
+
+// streamPositions returns the positions of the stream underlying a run length
+// encoded writer, or nil if the stream does not record positions.
+func streamPositions(w io.ByteWriter) []uint64 {
+	if recorder, ok := w.(PositionRecorder); ok {
+		return recorder.Positions()
+	}
+	return nil
+}
@@ -5,9 +5,11 @@
 Package proto is a generated protocol buffer package.
 
 It is generated from these files:
+
 	orc.proto
 
 It has these top-level messages:
+
 	IntegerStatistics
 	DoubleStatistics
 	StringStatistics
@@ -196,24 +198,25 @@ func (ColumnEncoding_Kind) EnumDescriptor() ([]byte, []int) { return fileDescrip
 type Type_Kind int32
 
 const (
-	Type_BOOLEAN   Type_Kind = 0
-	Type_BYTE      Type_Kind = 1
-	Type_SHORT     Type_Kind = 2
-	Type_INT       Type_Kind = 3
-	Type_LONG      Type_Kind = 4
-	Type_FLOAT     Type_Kind = 5
-	Type_DOUBLE    Type_Kind = 6
-	Type_STRING    Type_Kind = 7
-	Type_BINARY    Type_Kind = 8
-	Type_TIMESTAMP Type_Kind = 9
-	Type_LIST      Type_Kind = 10
-	Type_MAP       Type_Kind = 11
-	Type_STRUCT    Type_Kind = 12
-	Type_UNION     Type_Kind = 13
-	Type_DECIMAL   Type_Kind = 14
-	Type_DATE      Type_Kind = 15
-	Type_VARCHAR   Type_Kind = 16
-	Type_CHAR      Type_Kind = 17
+	Type_BOOLEAN           Type_Kind = 0
+	Type_BYTE              Type_Kind = 1
+	Type_SHORT             Type_Kind = 2
+	Type_INT               Type_Kind = 3
+	Type_LONG              Type_Kind = 4
+	Type_FLOAT             Type_Kind = 5
+	Type_DOUBLE            Type_Kind = 6
+	Type_STRING            Type_Kind = 7
+	Type_BINARY            Type_Kind = 8
+	Type_TIMESTAMP         Type_Kind = 9
+	Type_LIST              Type_Kind = 10
+	Type_MAP               Type_Kind = 11
+	Type_STRUCT            Type_Kind = 12
+	Type_UNION             Type_Kind = 13
+	Type_DECIMAL           Type_Kind = 14
+	Type_DATE              Type_Kind = 15
+	Type_VARCHAR           Type_Kind = 16
+	Type_CHAR              Type_Kind = 17
+	Type_TIMESTAMP_INSTANT Type_Kind = 18
 )
 
 var Type_Kind_name = map[int32]string{
@@ -235,26 +238,28 @@ var Type_Kind_name = map[int32]string{
 	15: "DATE",
 	16: "VARCHAR",
 	17: "CHAR",
+	18: "TIMESTAMP_INSTANT",
 }
 var Type_Kind_value = map[string]int32{
-	"BOOLEAN":   0,
-	"BYTE":      1,
-	"SHORT":     2,
-	"INT":       3,
-	"LONG":      4,
-	"FLOAT":     5,
-	"DOUBLE":    6,
-	"STRING":    7,
-	"BINARY":    8,
-	"TIMESTAMP": 9,
-	"LIST":      10,
-	"MAP":       11,
-	"STRUCT":    12,
-	"UNION":     13,
-	"DECIMAL":   14,
-	"DATE":      15,
-	"VARCHAR":   16,
-	"CHAR":      17,
+	"BOOLEAN":           0,
+	"BYTE":              1,
+	"SHORT":             2,
+	"INT":               3,
+	"LONG":              4,
+	"FLOAT":             5,
+	"DOUBLE":            6,
+	"STRING":            7,
+	"BINARY":            8,
+	"TIMESTAMP":         9,
+	"LIST":              10,
+	"MAP":               11,
+	"STRUCT":            12,
+	"UNION":             13,
+	"DECIMAL":           14,
+	"DATE":              15,
+	"VARCHAR":           16,
+	"CHAR":              17,
+	"TIMESTAMP_INSTANT": 18,
 }
 
 func (x Type_Kind) Enum() *Type_Kind {
@@ -1215,105 +1220,105 @@ func init() {
 func init() { proto1.RegisterFile("orc.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1590 bytes of a gzipped FileDescriptorProto
+	// 1600 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xcd, 0x6e, 0xdb, 0xc6,
 	0x16, 0xbe, 0x94, 0xa8, 0xbf, 0x23, 0x4b, 0x19, 0x4d, 0x9c, 0x5c, 0x21, 0x08, 0x02, 0x5f, 0x22,
 	0x37, 0xd7, 0x08, 0x2e, 0x7c, 0x71, 0xd5, 0xa0, 0x4d, 0x81, 0x36, 0x80, 0x7e, 0x28, 0x9b, 0xa8,
 	0x4c, 0x1a, 0x23, 0xda, 0x8d, 0xb3, 0x31, 0x68, 0x6a, 0x6c, 0xb3, 0x11, 0x49, 0x95, 0xa4, 0x92,
-	0x38, 0x6f, 0xd0, 0x5d, 0xd7, 0xed, 0xaa, 0x6f, 0xd0, 0x4d, 0xf7, 0x7d, 0x85, 0x02, 0x7d, 0x9b,
-	0xa2, 0x45, 0x31, 0x3f, 0x94, 0x48, 0xca, 0xce, 0xa6, 0x5d, 0x49, 0xf3, 0x9d, 0x33, 0x87, 0x67,
-	0xbe, 0xf3, 0x9d, 0x33, 0x03, 0x8d, 0x30, 0x72, 0xf7, 0x16, 0x51, 0x98, 0x84, 0xb8, 0xc2, 0x7f,
-	0xb4, 0x53, 0xe8, 0x18, 0x41, 0x42, 0x2f, 0x69, 0x34, 0x4d, 0x9c, 0xc4, 0x8b, 0x13, 0xcf, 0x8d,
-	0x71, 0x17, 0x6a, 0xbe, 0x17, 0x78, 0xfe, 0xd2, 0xef, 0x2a, 0x3b, 0xca, 0x2e, 0x26, 0xe9, 0x92,
-	0x5b, 0x9c, 0x77, 0xdc, 0x52, 0x92, 0x16, 0xb1, 0xc4, 0x08, 0xca, 0xf1, 0xd2, 0xef, 0x96, 0x39,
-	0xca, 0xfe, 0x6a, 0x2f, 0x01, 0x8d, 0xc2, 0xe5, 0xf9, 0x9c, 0xde, 0x1e, 0x59, 0xb9, 0x35, 0xb2,
-	0x72, 0x63, 0x64, 0x65, 0x15, 0x79, 0x9a, 0x44, 0x5e, 0x70, 0x79, 0x7b, 0xe4, 0xc6, 0xad, 0x91,
-	0x1b, 0x1f, 0xca, 0xf9, 0xbf, 0x80, 0x06, 0x4b, 0xf7, 0x35, 0x4d, 0x72, 0x91, 0x2b, 0x6e, 0xb8,
-	0x0c, 0x92, 0xae, 0xb2, 0x53, 0xde, 0x55, 0x07, 0x25, 0xa4, 0x10, 0x01, 0x30, 0xf2, 0x46, 0xd4,
-	0xf5, 0x7c, 0x67, 0xfe, 0xf7, 0x25, 0xd2, 0x10, 0x89, 0x8c, 0xa0, 0x3d, 0x72, 0x92, 0x0f, 0x50,
-	0xd7, 0xb9, 0x35, 0x6e, 0x67, 0x15, 0x57, 0xfb, 0x46, 0x81, 0xbb, 0xb6, 0xe7, 0xd3, 0x38, 0x71,
-	0xfc, 0xc5, 0x5f, 0x2c, 0xf0, 0x23, 0x00, 0xe9, 0x74, 0x9c, 0xb8, 0x92, 0xb3, 0x0c, 0xc2, 0xed,
-	0xc2, 0x95, 0xd9, 0x55, 0x69, 0x5f, 0x21, 0xda, 0x63, 0x40, 0x03, 0x2f, 0x70, 0xa2, 0xeb, 0x4c,
-	0x1e, 0xf2, 0xdc, 0xca, 0xba, 0x00, 0xbf, 0xab, 0x80, 0x86, 0xe1, 0x7c, 0xe9, 0x07, 0x19, 0xb7,
-	0x27, 0xd0, 0x0e, 0x96, 0xfe, 0x39, 0x8d, 0xac, 0x8b, 0x13, 0x67, 0xbe, 0xa4, 0x31, 0xdf, 0xa1,
-	0x92, 0x02, 0x8a, 0x5f, 0x40, 0xcb, 0x0b, 0x32, 0xa5, 0xe3, 0x47, 0x68, 0xf6, 0xba, 0x42, 0xf2,
-	0x7b, 0x1b, 0x42, 0x27, 0x79, 0x77, 0x3c, 0x04, 0x34, 0x2b, 0x28, 0x96, 0x1f, 0xb4, 0xd9, 0xfb,
-	0xa7, 0x0c, 0x51, 0x14, 0x34, 0xd9, 0xd8, 0xc0, 0x82, 0xc4, 0x05, 0x71, 0x72, 0x36, 0xd6, 0x41,
-	0x8a, 0xda, 0x25, 0x1b, 0x1b, 0x58, 0x90, 0xf3, 0x82, 0x0e, 0xbb, 0x95, 0x5c, 0x90, 0xa2, 0x4c,
-	0xc9, 0xc6, 0x06, 0x3c, 0x86, 0xce, 0xac, 0x28, 0xcf, 0x6e, 0x35, 0x47, 0xc9, 0x86, 0x7c, 0xc9,
-	0xe6, 0x16, 0xfc, 0x39, 0xb4, 0x67, 0x39, 0x2d, 0x76, 0x6b, 0x3c, 0xc8, 0xbd, 0x34, 0x48, 0xce,
-	0x48, 0x0a, 0xce, 0xfc, 0x2c, 0x85, 0xc2, 0x77, 0xeb, 0xf9, 0xb3, 0x14, 0xcc, 0x64, 0x63, 0x03,
-	0x9e, 0xc0, 0xdd, 0x64, 0x53, 0xc8, 0xdd, 0x06, 0x8f, 0xf3, 0x40, 0xc6, 0xb9, 0x41, 0xea, 0xe4,
-	0xa6, 0x6d, 0x4c, 0xe5, 0x57, 0x4e, 0x6c, 0x2e, 0xe7, 0xf3, 0x2e, 0xec, 0x28, 0xbb, 0x75, 0x92,
-	0x2e, 0xb5, 0xaf, 0xa0, 0x45, 0xc2, 0xb7, 0x46, 0x30, 0xa3, 0xef, 0xf4, 0x20, 0x89, 0xae, 0xf1,
-	0x0e, 0x34, 0x16, 0x61, 0xec, 0x25, 0x5e, 0x18, 0xc4, 0x99, 0x09, 0xb0, 0x06, 0xf1, 0x27, 0x00,
-	0x71, 0x51, 0x72, 0xe9, 0xc9, 0x8a, 0x52, 0x26, 0x19, 0x57, 0xed, 0x63, 0xa8, 0xa7, 0xdf, 0xc2,
-	0x4f, 0xa1, 0x42, 0xd9, 0xf7, 0xf8, 0x27, 0x9a, 0xbd, 0x6d, 0xb9, 0x3f, 0x97, 0x0b, 0x11, 0x2e,
-	0xda, 0xd7, 0xd0, 0x1c, 0xcc, 0xc3, 0xd0, 0x1f, 0x7b, 0xf3, 0x84, 0x46, 0xf8, 0x29, 0xa0, 0x60,
-	0xe9, 0x1f, 0x38, 0xf1, 0xd5, 0x78, 0x19, 0xb8, 0x69, 0xa2, 0xca, 0x6e, 0x8b, 0x6c, 0xe0, 0xf8,
-	0x3e, 0x54, 0xcf, 0xbd, 0x24, 0xa6, 0x49, 0xb7, 0xb4, 0x53, 0xde, 0xad, 0x12, 0xb9, 0x62, 0xcd,
-	0xbb, 0x4c, 0x2e, 0x9e, 0x4b, 0x1b, 0xd3, 0xfc, 0x16, 0xc9, 0x20, 0xda, 0x01, 0xa0, 0xcc, 0x27,
-	0x45, 0xca, 0xcf, 0xa0, 0x79, 0xbe, 0xc6, 0x64, 0xe2, 0x38, 0x2d, 0xe9, 0xda, 0x42, 0xb2, 0x6e,
-	0xda, 0x1f, 0x0a, 0x54, 0xa7, 0x49, 0x44, 0x1d, 0x1f, 0x3f, 0x01, 0xf5, 0xb5, 0x17, 0xcc, 0x78,
-	0xb2, 0xed, 0xd5, 0x4e, 0x61, 0xdc, 0xfb, 0xc2, 0x0b, 0x66, 0x84, 0xdb, 0x59, 0xd2, 0x2e, 0xe7,
-	0x91, 0x93, 0xdb, 0x22, 0x72, 0xc5, 0xf0, 0x39, 0x0d, 0x2e, 0x93, 0x2b, 0x9e, 0xb0, 0x4a, 0xe4,
-	0x4a, 0xfb, 0x5e, 0x01, 0x95, 0x6d, 0xc7, 0x4d, 0xa8, 0x1d, 0x11, 0x7d, 0xaa, 0x9b, 0x36, 0xfa,
-	0x07, 0xae, 0x83, 0x3a, 0xea, 0xdb, 0x7d, 0xa4, 0x60, 0x80, 0xea, 0x44, 0x37, 0xf7, 0xed, 0x03,
-	0x54, 0xc2, 0x77, 0xe1, 0xce, 0xc8, 0x18, 0xda, 0x86, 0x65, 0xf6, 0xc9, 0xe9, 0x19, 0x77, 0x28,
-	0xe3, 0x6d, 0x40, 0x19, 0x70, 0x68, 0x1d, 0x9b, 0x36, 0x52, 0x71, 0x0b, 0x1a, 0x53, 0x7d, 0x68,
-	0x99, 0xa3, 0x3e, 0x39, 0x45, 0x15, 0xb6, 0x24, 0xd6, 0x97, 0x67, 0x86, 0x39, 0xd2, 0x5f, 0xa2,
-	0x2a, 0x46, 0xb0, 0x35, 0x98, 0x58, 0xd6, 0xe1, 0xd9, 0xd8, 0x98, 0xd8, 0x3a, 0x41, 0x35, 0x7c,
-	0x0f, 0x3a, 0x59, 0xe4, 0xec, 0xd8, 0x1e, 0x3f, 0x47, 0x75, 0xed, 0x57, 0x05, 0xda, 0x42, 0x16,
-	0x7a, 0xe0, 0x86, 0x33, 0x2f, 0xb8, 0xc4, 0x7b, 0x39, 0x22, 0x1e, 0xe4, 0xb4, 0x93, 0x3a, 0x65,
-	0x09, 0x79, 0x02, 0xed, 0x99, 0xc7, 0x2b, 0xca, 0x9a, 0xc4, 0x7b, 0x4f, 0x25, 0x31, 0x05, 0x14,
-	0x3f, 0x86, 0x16, 0xa7, 0x3e, 0x8d, 0xc1, 0x79, 0x6a, 0x91, 0x3c, 0xa8, 0x8d, 0x24, 0x5b, 0x00,
-	0xd5, 0x91, 0x41, 0xf4, 0x21, 0x23, 0xab, 0x0d, 0xb0, 0x66, 0x00, 0x29, 0xec, 0xb0, 0xc2, 0x76,
-	0x76, 0xd2, 0x43, 0x25, 0xdc, 0x81, 0x56, 0x86, 0xa0, 0x93, 0x1e, 0x2a, 0x6b, 0xdf, 0x2a, 0xb0,
-	0xc5, 0x06, 0xdb, 0x82, 0x8e, 0xc3, 0x90, 0xc9, 0xf2, 0x3f, 0x50, 0x8b, 0x79, 0x29, 0x63, 0x29,
-	0x8d, 0x56, 0xae, 0xc0, 0x24, 0xb5, 0xe2, 0xff, 0x41, 0x4d, 0x14, 0x34, 0xe6, 0xa2, 0x5c, 0xcf,
-	0x95, 0x3c, 0x01, 0x24, 0xf5, 0x62, 0xc7, 0x7f, 0x1b, 0x79, 0x09, 0x8d, 0x58, 0xbf, 0xbf, 0x0f,
-	0x03, 0x2a, 0x2f, 0xce, 0x02, 0xaa, 0x7d, 0x57, 0x06, 0xd5, 0xbe, 0x5e, 0x30, 0x1e, 0xb2, 0xfc,
-	0xa2, 0x74, 0x5a, 0x5c, 0x2f, 0x68, 0x96, 0xd5, 0x47, 0x50, 0x8f, 0x97, 0xe7, 0xc9, 0xf5, 0x82,
-	0x8a, 0x44, 0x5a, 0xbc, 0xd1, 0x57, 0x18, 0xeb, 0x91, 0x0b, 0x8f, 0xce, 0x67, 0xa6, 0xe3, 0x53,
-	0x76, 0x2f, 0x94, 0x77, 0x1b, 0x24, 0x83, 0x30, 0xb6, 0xe5, 0x75, 0x37, 0x11, 0xaa, 0x54, 0x05,
-	0xdb, 0x39, 0x10, 0x3f, 0x84, 0xc6, 0x22, 0xa2, 0xae, 0x17, 0x7b, 0x61, 0xc0, 0x47, 0x7a, 0x8b,
-	0xac, 0x01, 0xbc, 0x0d, 0x95, 0xd8, 0x75, 0xe6, 0x94, 0x8f, 0xe9, 0x16, 0x11, 0x0b, 0xed, 0x97,
-	0x8c, 0xa0, 0x07, 0x96, 0x35, 0xd1, 0xfb, 0xa6, 0x10, 0xf4, 0xe0, 0xd4, 0xd6, 0x91, 0x82, 0x1b,
-	0x50, 0x99, 0x1e, 0x58, 0xc4, 0x46, 0x25, 0x5c, 0x83, 0xb2, 0x61, 0xda, 0xa8, 0xcc, 0xac, 0x13,
-	0xcb, 0xdc, 0x47, 0x2a, 0xb3, 0x8e, 0x27, 0x56, 0xdf, 0x46, 0x15, 0x5e, 0x62, 0xeb, 0x78, 0x30,
-	0xd1, 0x51, 0x95, 0xfd, 0x9f, 0xda, 0xc4, 0x30, 0xf7, 0x51, 0x8d, 0xfd, 0x1f, 0x18, 0xbc, 0xd4,
-	0x75, 0x56, 0x6a, 0xdb, 0x38, 0xd4, 0xa7, 0x76, 0xff, 0xf0, 0x08, 0x35, 0x78, 0x1c, 0x63, 0x6a,
-	0x23, 0x60, 0xa1, 0x0f, 0xfb, 0x47, 0xa8, 0x29, 0x77, 0x1e, 0x0f, 0x6d, 0xb4, 0xc5, 0x82, 0x1f,
-	0x9b, 0x86, 0x65, 0xa2, 0x16, 0x4b, 0x6e, 0xa4, 0x0f, 0x8d, 0xc3, 0xfe, 0x04, 0xb5, 0x65, 0xb7,
-	0xe9, 0xe8, 0x0e, 0x83, 0x4f, 0xfa, 0x64, 0x78, 0xd0, 0x27, 0x08, 0x31, 0x98, 0xff, 0xeb, 0x68,
-	0x3f, 0x29, 0xd0, 0x11, 0x7a, 0x31, 0x82, 0x8b, 0x30, 0xf2, 0x1d, 0x26, 0x5c, 0xd6, 0xd2, 0xe1,
-	0xc5, 0x05, 0x9b, 0x41, 0xe2, 0x86, 0x97, 0x2b, 0xbc, 0x03, 0x4d, 0x8f, 0x0d, 0x1d, 0xc9, 0x6c,
-	0x89, 0x1b, 0xb3, 0x10, 0xab, 0xce, 0xcc, 0x49, 0x9c, 0x49, 0x76, 0x20, 0x64, 0x10, 0xac, 0xc1,
-	0xd6, 0x05, 0x17, 0x66, 0xa6, 0x38, 0x2a, 0xc9, 0x61, 0xcc, 0x27, 0x7d, 0x51, 0x90, 0xf0, 0xad,
-	0xb8, 0x71, 0x55, 0x92, 0xc3, 0xb4, 0xcf, 0x00, 0x1d, 0xc7, 0x34, 0x3a, 0xa4, 0x89, 0xc3, 0xa2,
-	0x1b, 0x09, 0xf5, 0x31, 0x06, 0x35, 0x70, 0x7c, 0x2a, 0xdf, 0x7b, 0xfc, 0x3f, 0xab, 0xe4, 0x1b,
-	0xf6, 0x2a, 0xe1, 0xb9, 0x6e, 0x11, 0xb1, 0xd0, 0xf6, 0xc5, 0xcb, 0x75, 0x91, 0xbd, 0x1f, 0x3f,
-	0x82, 0xba, 0x1b, 0xf2, 0xfb, 0x36, 0xed, 0x94, 0x5b, 0x6f, 0x8f, 0x95, 0xa3, 0xa6, 0x43, 0x3d,
-	0x4d, 0x01, 0x7f, 0x0a, 0xcd, 0x78, 0x15, 0xb4, 0x18, 0xa3, 0xf8, 0x39, 0x92, 0xf5, 0xd5, 0x7e,
-	0x2b, 0x41, 0x55, 0xf6, 0xab, 0x06, 0x5b, 0x57, 0xd4, 0x99, 0xad, 0x08, 0x12, 0x05, 0xc8, 0x61,
-	0x4c, 0xe2, 0x6e, 0x18, 0x24, 0x34, 0x48, 0x72, 0x85, 0xc8, 0x83, 0xb8, 0xc7, 0x3b, 0xdf, 0x5b,
-	0xc8, 0x2e, 0x59, 0xbf, 0x36, 0x36, 0xea, 0x4d, 0x52, 0x47, 0xfc, 0x2f, 0xa8, 0x88, 0xce, 0x53,
-	0xf9, 0x8e, 0x66, 0xa6, 0x47, 0x89, 0xb0, 0x30, 0x9e, 0x7c, 0x79, 0xe4, 0x6e, 0x25, 0x77, 0xc6,
-	0x62, 0x41, 0xc8, 0xca, 0x71, 0xa3, 0xa4, 0xd5, 0xcd, 0x92, 0x16, 0x2e, 0xf0, 0xda, 0x87, 0x4b,
-	0x90, 0x71, 0x65, 0x83, 0x28, 0x92, 0x17, 0x34, 0x3b, 0xda, 0x8c, 0xf2, 0x77, 0x4d, 0x8b, 0x14,
-	0x50, 0xa6, 0x6a, 0x31, 0x9a, 0xf8, 0x7b, 0xa5, 0x45, 0xe4, 0x4a, 0xfb, 0xa1, 0x04, 0x70, 0x14,
-	0xc6, 0xc9, 0xd4, 0x8d, 0xbc, 0x45, 0xb2, 0x21, 0x51, 0xe5, 0x06, 0x89, 0x3e, 0x87, 0xa6, 0x1b,
-	0xfa, 0x8b, 0x88, 0xc6, 0x7c, 0x80, 0x94, 0xf8, 0x44, 0xbb, 0xbf, 0x4a, 0x76, 0x65, 0xe1, 0x73,
-	0x2d, 0xeb, 0x8a, 0x7b, 0xb0, 0x9d, 0x59, 0x0e, 0xe6, 0xa1, 0xfb, 0x9a, 0x5f, 0x1d, 0xa2, 0x55,
-	0x6e, 0xb4, 0xe1, 0x87, 0x50, 0x7b, 0x43, 0x23, 0xfe, 0x25, 0x75, 0x35, 0x11, 0x53, 0x88, 0x1d,
-	0x3f, 0xe5, 0x59, 0x66, 0x2c, 0x1a, 0xa6, 0x80, 0x32, 0xd5, 0x88, 0x03, 0x9f, 0xc8, 0x58, 0x62,
-	0xb8, 0xe5, 0x41, 0x7c, 0x0f, 0x2a, 0xbe, 0x73, 0xe9, 0xb9, 0xdd, 0x9f, 0x5f, 0xf0, 0x36, 0x12,
-	0x2b, 0xed, 0x47, 0x05, 0xea, 0x63, 0x6f, 0x4e, 0x6d, 0xc7, 0x9b, 0xe3, 0xff, 0x03, 0x2c, 0xc2,
-	0x38, 0x89, 0x39, 0x5f, 0x9c, 0x9f, 0x66, 0xaf, 0x23, 0x0f, 0xbf, 0x26, 0x92, 0x64, 0x9c, 0xf0,
-	0xbf, 0xa1, 0x2a, 0x08, 0x94, 0x2f, 0xb3, 0xf4, 0x16, 0x12, 0xaa, 0x27, 0xd2, 0x28, 0x86, 0xfb,
-	0x9c, 0xe6, 0xc7, 0xc7, 0x1a, 0x61, 0x8f, 0xac, 0x75, 0xd0, 0xdc, 0x08, 0xd9, 0xc0, 0x9f, 0x1e,
-	0xc2, 0x9d, 0x42, 0x25, 0xd8, 0xdc, 0x33, 0x2d, 0x53, 0x17, 0x53, 0xfb, 0xd5, 0xc4, 0x18, 0x88,
-	0x67, 0xc8, 0xd4, 0xec, 0x1f, 0x1d, 0x9d, 0x8a, 0xb1, 0x3d, 0x79, 0x65, 0xa1, 0xb2, 0xf8, 0xf3,
-	0x0c, 0xa9, 0xdc, 0x6f, 0x6a, 0x8f, 0x50, 0xe5, 0xcf, 0x00, 0x00, 0x00, 0xff, 0xff, 0xa5, 0x53,
-	0x5a, 0x7c, 0xb5, 0x0f, 0x00, 0x00,
+	0x38, 0x6f, 0xd0, 0x5d, 0xf7, 0x5d, 0xf5, 0x0d, 0xda, 0x45, 0xf7, 0x7d, 0x88, 0x3e, 0x44, 0xdf,
+	0xa1, 0x68, 0x51, 0xcc, 0x0f, 0x25, 0x92, 0xb2, 0xb3, 0x69, 0x57, 0xd2, 0x7c, 0xe7, 0xcc, 0xe1,
+	0x99, 0xef, 0x7c, 0xe7, 0xcc, 0x40, 0x23, 0x8c, 0xdc, 0xbd, 0x45, 0x14, 0x26, 0x21, 0xae, 0xf0,
+	0x1f, 0xed, 0x14, 0x3a, 0x46, 0x90, 0xd0, 0x4b, 0x1a, 0x4d, 0x13, 0x27, 0xf1, 0xe2, 0xc4, 0x73,
+	0x63, 0xdc, 0x85, 0x9a, 0xef, 0x05, 0x9e, 0xbf, 0xf4, 0xbb, 0xca, 0x8e, 0xb2, 0x8b, 0x49, 0xba,
+	0xe4, 0x16, 0xe7, 0x1d, 0xb7, 0x94, 0xa4, 0x45, 0x2c, 0x31, 0x82, 0x72, 0xbc, 0xf4, 0xbb, 0x65,
+	0x8e, 0xb2, 0xbf, 0xda, 0x4b, 0x40, 0xa3, 0x70, 0x79, 0x3e, 0xa7, 0xb7, 0x47, 0x56, 0x6e, 0x8d,
+	0xac, 0xdc, 0x18, 0x59, 0x59, 0x45, 0x9e, 0x26, 0x91, 0x17, 0x5c, 0xde, 0x1e, 0xb9, 0x71, 0x6b,
+	0xe4, 0xc6, 0x87, 0x72, 0xfe, 0x2f, 0xa0, 0xc1, 0xd2, 0x7d, 0x4d, 0x93, 0x5c, 0xe4, 0x8a, 0x1b,
+	0x2e, 0x83, 0xa4, 0xab, 0xec, 0x94, 0x77, 0xd5, 0x41, 0x09, 0x29, 0x44, 0x00, 0x8c, 0xbc, 0x11,
+	0x75, 0x3d, 0xdf, 0x99, 0xff, 0x7d, 0x89, 0x34, 0x44, 0x22, 0x23, 0x68, 0x8f, 0x9c, 0xe4, 0x03,
+	0xd4, 0x75, 0x6e, 0x8d, 0xdb, 0x59, 0xc5, 0xd5, 0xbe, 0x51, 0xe0, 0xae, 0xed, 0xf9, 0x34, 0x4e,
+	0x1c, 0x7f, 0xf1, 0x17, 0x0b, 0xfc, 0x08, 0x40, 0x3a, 0x1d, 0x27, 0xae, 0xe4, 0x2c, 0x83, 0x70,
+	0xbb, 0xf3, 0x2e, 0xb5, 0xab, 0xd2, 0xbe, 0x42, 0xb4, 0xc7, 0x80, 0x06, 0x5e, 0xe0, 0x44, 0xd7,
+	0x99, 0x3c, 0xe4, 0xb9, 0x95, 0x75, 0x01, 0x7e, 0x57, 0x01, 0x0d, 0xc3, 0xf9, 0xd2, 0x0f, 0x32,
+	0x6e, 0x4f, 0xa0, 0x1d, 0x2c, 0xfd, 0x73, 0x1a, 0x59, 0x17, 0x27, 0xce, 0x7c, 0x49, 0x63, 0xbe,
+	0x43, 0x25, 0x05, 0x14, 0xbf, 0x80, 0x96, 0x17, 0x64, 0x4a, 0xc7, 0x8f, 0xd0, 0xec, 0x75, 0x85,
+	0xe4, 0xf7, 0x36, 0x84, 0x4e, 0xf2, 0xee, 0x78, 0x08, 0x68, 0x56, 0x50, 0x2c, 0x3f, 0x68, 0xb3,
+	0xf7, 0x4f, 0x19, 0xa2, 0x28, 0x68, 0xb2, 0xb1, 0x81, 0x05, 0x89, 0x0b, 0xe2, 0xec, 0xaa, 0xb9,
+	0x20, 0x45, 0xed, 0x92, 0x8d, 0x0d, 0x2c, 0xc8, 0x79, 0x41, 0x87, 0xdd, 0x4a, 0x2e, 0x48, 0x51,
+	0xa6, 0x64, 0x63, 0x03, 0x1e, 0x43, 0x67, 0x56, 0x94, 0x67, 0xb7, 0x9a, 0xa3, 0x64, 0x43, 0xbe,
+	0x64, 0x73, 0x0b, 0xfe, 0x1c, 0xda, 0xb3, 0x9c, 0x16, 0xbb, 0x35, 0x1e, 0xe4, 0x5e, 0x1a, 0x24,
+	0x67, 0x24, 0x05, 0x67, 0x7e, 0x96, 0x42, 0xe1, 0xbb, 0xf5, 0xfc, 0x59, 0x0a, 0x66, 0xb2, 0xb1,
+	0x01, 0x4f, 0xe0, 0x6e, 0xb2, 0x29, 0xe4, 0x6e, 0x83, 0xc7, 0x79, 0x20, 0xe3, 0xdc, 0x20, 0x75,
+	0x72, 0xd3, 0x36, 0xa6, 0xf2, 0x2b, 0x27, 0x36, 0x97, 0xf3, 0x79, 0x17, 0x76, 0x94, 0xdd, 0x3a,
+	0x49, 0x97, 0xda, 0x57, 0xd0, 0x22, 0xe1, 0x5b, 0x23, 0x98, 0xd1, 0x77, 0x7a, 0x90, 0x44, 0xd7,
+	0x78, 0x07, 0x1a, 0x8b, 0x30, 0xf6, 0x12, 0x2f, 0x0c, 0xe2, 0xcc, 0x04, 0x58, 0x83, 0xf8, 0x13,
+	0x80, 0xb8, 0x28, 0xb9, 0xf4, 0x64, 0x45, 0x29, 0x93, 0x8c, 0xab, 0xf6, 0x31, 0xd4, 0xd3, 0x6f,
+	0xe1, 0xa7, 0x50, 0xa1, 0xec, 0x7b, 0xfc, 0x13, 0xcd, 0xde, 0xb6, 0xdc, 0x9f, 0xcb, 0x85, 0x08,
+	0x17, 0xed, 0x6b, 0x68, 0x0e, 0xe6, 0x61, 0xe8, 0x8f, 0xbd, 0x79, 0x42, 0x23, 0xfc, 0x14, 0x50,
+	0xb0, 0xf4, 0x0f, 0x9c, 0xf8, 0x6a, 0xbc, 0x0c, 0xdc, 0x34, 0x51, 0x65, 0xb7, 0x45, 0x36, 0x70,
+	0x7c, 0x1f, 0xaa, 0xe7, 0x5e, 0x12, 0xd3, 0xa4, 0x5b, 0xda, 0x29, 0xef, 0x56, 0x89, 0x5c, 0xb1,
+	0xe6, 0x5d, 0x26, 0x17, 0xcf, 0xa5, 0x8d, 0x69, 0x7e, 0x8b, 0x64, 0x10, 0xed, 0x00, 0x50, 0xe6,
+	0x93, 0x22, 0xe5, 0x67, 0xd0, 0x3c, 0x5f, 0x63, 0x32, 0x71, 0x9c, 0x96, 0x74, 0x6d, 0x21, 0x59,
+	0x37, 0xed, 0x0f, 0x05, 0xaa, 0xd3, 0x24, 0xa2, 0x8e, 0x8f, 0x9f, 0x80, 0xfa, 0xda, 0x0b, 0x66,
+	0x3c, 0xd9, 0xf6, 0x6a, 0xa7, 0x30, 0xee, 0x7d, 0xe1, 0x05, 0x33, 0xc2, 0xed, 0x2c, 0x69, 0x97,
+	0xf3, 0xc8, 0xc9, 0x6d, 0x11, 0xb9, 0x62, 0xf8, 0x9c, 0x06, 0x97, 0xc9, 0x15, 0x4f, 0x58, 0x25,
+	0x72, 0xa5, 0x7d, 0xa7, 0x80, 0xca, 0xb6, 0xe3, 0x26, 0xd4, 0x8e, 0x88, 0x3e, 0xd5, 0x4d, 0x1b,
+	0xfd, 0x03, 0xd7, 0x41, 0x1d, 0xf5, 0xed, 0x3e, 0x52, 0x30, 0x40, 0x75, 0xa2, 0x9b, 0xfb, 0xf6,
+	0x01, 0x2a, 0xe1, 0xbb, 0x70, 0x67, 0x64, 0x0c, 0x6d, 0xc3, 0x32, 0xfb, 0xe4, 0xf4, 0x8c, 0x3b,
+	0x94, 0xf1, 0x36, 0xa0, 0x0c, 0x38, 0xb4, 0x8e, 0x4d, 0x1b, 0xa9, 0xb8, 0x05, 0x8d, 0xa9, 0x3e,
+	0xb4, 0xcc, 0x51, 0x9f, 0x9c, 0xa2, 0x0a, 0x5b, 0x12, 0xeb, 0xcb, 0x33, 0xc3, 0x1c, 0xe9, 0x2f,
+	0x51, 0x15, 0x23, 0xd8, 0x1a, 0x4c, 0x2c, 0xeb, 0xf0, 0x6c, 0x6c, 0x4c, 0x6c, 0x9d, 0xa0, 0x1a,
+	0xbe, 0x07, 0x9d, 0x2c, 0x72, 0x76, 0x6c, 0x8f, 0x9f, 0xa3, 0xba, 0xf6, 0x8b, 0x02, 0x6d, 0x21,
+	0x0b, 0x3d, 0x70, 0xc3, 0x99, 0x17, 0x5c, 0xe2, 0xbd, 0x1c, 0x11, 0x0f, 0x72, 0xda, 0x49, 0x9d,
+	0xb2, 0x84, 0x3c, 0x81, 0xf6, 0xcc, 0xe3, 0x15, 0x65, 0x4d, 0xe2, 0xbd, 0xa7, 0x92, 0x98, 0x02,
+	0x8a, 0x1f, 0x43, 0x8b, 0x53, 0x9f, 0xc6, 0xe0, 0x3c, 0xb5, 0x48, 0x1e, 0xd4, 0x46, 0x92, 0x2d,
+	0x80, 0xea, 0xc8, 0x20, 0xfa, 0x90, 0x91, 0xd5, 0x06, 0x58, 0x33, 0x80, 0x14, 0x76, 0x58, 0x61,
+	0x3b, 0x3b, 0xe9, 0xa1, 0x12, 0xee, 0x40, 0x2b, 0x43, 0xd0, 0x49, 0x0f, 0x95, 0xb5, 0x6f, 0x15,
+	0xd8, 0x62, 0x83, 0x6d, 0x41, 0xc7, 0x61, 0xc8, 0x64, 0xf9, 0x1f, 0xa8, 0xc5, 0xbc, 0x94, 0xb1,
+	0x94, 0x46, 0x2b, 0x57, 0x60, 0x92, 0x5a, 0xf1, 0xff, 0xa0, 0x26, 0x0a, 0x1a, 0x73, 0x51, 0xae,
+	0xe7, 0x4a, 0x9e, 0x00, 0x92, 0x7a, 0xb1, 0xe3, 0xbf, 0x8d, 0xbc, 0x84, 0x46, 0xac, 0xdf, 0xdf,
+	0x87, 0x01, 0x95, 0x17, 0x67, 0x01, 0xd5, 0x7e, 0x2c, 0x83, 0x6a, 0x5f, 0x2f, 0x18, 0x0f, 0x59,
+	0x7e, 0x51, 0x3a, 0x2d, 0xae, 0x17, 0x34, 0xcb, 0xea, 0x23, 0xa8, 0xc7, 0xcb, 0xf3, 0xe4, 0x7a,
+	0x41, 0x45, 0x22, 0x2d, 0xde, 0xe8, 0x2b, 0x8c, 0xf5, 0xc8, 0x85, 0x47, 0xe7, 0x33, 0xd3, 0xf1,
+	0x29, 0xbb, 0x17, 0xca, 0xbb, 0x0d, 0x92, 0x41, 0x18, 0xdb, 0xf2, 0xba, 0x9b, 0x08, 0x55, 0xaa,
+	0x82, 0xed, 0x1c, 0x88, 0x1f, 0x42, 0x63, 0x11, 0x51, 0xd7, 0x8b, 0xbd, 0x30, 0xe0, 0x23, 0xbd,
+	0x45, 0xd6, 0x00, 0xde, 0x86, 0x4a, 0xec, 0x3a, 0x73, 0xca, 0xc7, 0x74, 0x8b, 0x88, 0x85, 0xf6,
+	0x6b, 0x46, 0xd0, 0x03, 0xcb, 0x9a, 0xe8, 0x7d, 0x53, 0x08, 0x7a, 0x70, 0x6a, 0xeb, 0x48, 0xc1,
+	0x0d, 0xa8, 0x4c, 0x0f, 0x2c, 0x62, 0xa3, 0x12, 0xae, 0x41, 0xd9, 0x30, 0x6d, 0x54, 0x66, 0xd6,
+	0x89, 0x65, 0xee, 0x23, 0x95, 0x59, 0xc7, 0x13, 0xab, 0x6f, 0xa3, 0x0a, 0x2f, 0xb1, 0x75, 0x3c,
+	0x98, 0xe8, 0xa8, 0xca, 0xfe, 0x4f, 0x6d, 0x62, 0x98, 0xfb, 0xa8, 0xc6, 0xfe, 0x0f, 0x0c, 0x5e,
+	0xea, 0x3a, 0x2b, 0xb5, 0x6d, 0x1c, 0xea, 0x53, 0xbb, 0x7f, 0x78, 0x84, 0x1a, 0x3c, 0x8e, 0x31,
+	0xb5, 0x11, 0xb0, 0xd0, 0x87, 0xfd, 0x23, 0xd4, 0x94, 0x3b, 0x8f, 0x87, 0x36, 0xda, 0x62, 0xc1,
+	0x8f, 0x4d, 0xc3, 0x32, 0x51, 0x8b, 0x25, 0x37, 0xd2, 0x87, 0xc6, 0x61, 0x7f, 0x82, 0xda, 0xb2,
+	0xdb, 0x74, 0x74, 0x87, 0xc1, 0x27, 0x7d, 0x32, 0x3c, 0xe8, 0x13, 0x84, 0x18, 0xcc, 0xff, 0x75,
+	0x58, 0x77, 0xac, 0x3e, 0x73, 0x66, 0x98, 0x53, 0xbb, 0x6f, 0xda, 0x08, 0x6b, 0x3f, 0x29, 0xd0,
+	0x11, 0x32, 0x32, 0x82, 0x8b, 0x30, 0xf2, 0x1d, 0xa6, 0x67, 0xd6, 0xe9, 0xe1, 0xc5, 0x05, 0x1b,
+	0x4d, 0xe2, 0xe2, 0x97, 0x2b, 0xbc, 0x03, 0x4d, 0x8f, 0xcd, 0x22, 0x49, 0x78, 0x89, 0x1b, 0xb3,
+	0x10, 0x2b, 0xda, 0xcc, 0x49, 0x9c, 0x49, 0x76, 0x4e, 0x64, 0x10, 0xac, 0xc1, 0xd6, 0x05, 0xd7,
+	0x6b, 0xa6, 0x66, 0x2a, 0xc9, 0x61, 0xcc, 0x27, 0x7d, 0x68, 0x90, 0xf0, 0xad, 0xb8, 0x88, 0x55,
+	0x92, 0xc3, 0xb4, 0xcf, 0x00, 0x1d, 0xc7, 0x34, 0x3a, 0xa4, 0x89, 0xc3, 0xa2, 0x1b, 0x09, 0xf5,
+	0x31, 0x06, 0x35, 0x70, 0x7c, 0x2a, 0x9f, 0x81, 0xfc, 0x3f, 0x2b, 0xf0, 0x1b, 0xf6, 0x58, 0xe1,
+	0xb9, 0x6e, 0x11, 0xb1, 0xd0, 0xf6, 0xc5, 0x83, 0x76, 0x91, 0xbd, 0x36, 0x3f, 0x82, 0xba, 0x1b,
+	0xf2, 0x6b, 0x38, 0x6d, 0xa0, 0x5b, 0x2f, 0x95, 0x95, 0xa3, 0xa6, 0x43, 0x3d, 0x4d, 0x01, 0x7f,
+	0x0a, 0xcd, 0x78, 0x15, 0xb4, 0x18, 0xa3, 0xf8, 0x39, 0x92, 0xf5, 0xd5, 0x7e, 0x2b, 0x41, 0x55,
+	0xb6, 0xb1, 0x06, 0x5b, 0x57, 0xd4, 0x99, 0xad, 0x08, 0x12, 0x05, 0xc8, 0x61, 0x4c, 0xf9, 0x6e,
+	0x18, 0x24, 0x34, 0x48, 0x72, 0x85, 0xc8, 0x83, 0xb8, 0xc7, 0x07, 0x82, 0xb7, 0x90, 0xcd, 0xb3,
+	0x7e, 0x84, 0x6c, 0xd4, 0x9b, 0xa4, 0x8e, 0xf8, 0x5f, 0x50, 0x11, 0x0d, 0xa9, 0xf2, 0x1d, 0xcd,
+	0x4c, 0xeb, 0x12, 0x61, 0x61, 0x3c, 0xf9, 0xf2, 0xc8, 0xdd, 0x4a, 0xee, 0x8c, 0xc5, 0x82, 0x90,
+	0x95, 0xe3, 0x46, 0x49, 0xab, 0x9b, 0x25, 0x2d, 0xdc, 0xeb, 0xb5, 0x0f, 0x97, 0x20, 0xe3, 0xca,
+	0xe6, 0x53, 0x24, 0xef, 0x6d, 0x76, 0xb4, 0x19, 0xe5, 0xcf, 0x9d, 0x16, 0x29, 0xa0, 0x4c, 0xd5,
+	0x62, 0x62, 0xf1, 0x67, 0x4c, 0x8b, 0xc8, 0x95, 0xf6, 0x7d, 0x09, 0xe0, 0x28, 0x8c, 0x93, 0xa9,
+	0x1b, 0x79, 0x8b, 0x64, 0x43, 0xa2, 0xca, 0x0d, 0x12, 0x7d, 0x0e, 0x4d, 0x37, 0xf4, 0x17, 0x11,
+	0x8d, 0xf9, 0x5c, 0x29, 0xf1, 0x41, 0x77, 0x7f, 0x95, 0xec, 0xca, 0xc2, 0xc7, 0x5d, 0xd6, 0x15,
+	0xf7, 0x60, 0x3b, 0xb3, 0x1c, 0xcc, 0x43, 0xf7, 0x35, 0xbf, 0x51, 0x44, 0xab, 0xdc, 0x68, 0xc3,
+	0x0f, 0xa1, 0xf6, 0x86, 0x46, 0xfc, 0x4b, 0xea, 0x6a, 0x50, 0xa6, 0x10, 0x3b, 0x7e, 0xca, 0xb3,
+	0xcc, 0x58, 0x34, 0x4c, 0x01, 0x65, 0xaa, 0x11, 0x07, 0x3e, 0x91, 0xb1, 0xc4, 0xcc, 0xcb, 0x83,
+	0xf8, 0x1e, 0x54, 0x7c, 0xe7, 0xd2, 0x73, 0xbb, 0x3f, 0xbf, 0xe0, 0x6d, 0x24, 0x56, 0xda, 0x0f,
+	0x0a, 0xd4, 0xc7, 0xde, 0x9c, 0xda, 0x8e, 0x37, 0xc7, 0xff, 0x07, 0x58, 0x84, 0x71, 0x12, 0x73,
+	0xbe, 0x38, 0x3f, 0xcd, 0x5e, 0x47, 0x1e, 0x7e, 0x4d, 0x24, 0xc9, 0x38, 0xe1, 0x7f, 0x43, 0x55,
+	0x10, 0x28, 0x1f, 0x6c, 0xe9, 0xe5, 0x24, 0x54, 0x4f, 0xa4, 0x51, 0xcc, 0xfc, 0x39, 0xcd, 0x8f,
+	0x8f, 0x35, 0xc2, 0xde, 0x5e, 0xeb, 0xa0, 0xb9, 0x11, 0xb2, 0x81, 0x3f, 0x3d, 0x84, 0x3b, 0x85,
+	0x4a, 0xb0, 0x71, 0x68, 0x5a, 0xa6, 0x2e, 0x86, 0xf9, 0xab, 0x89, 0x31, 0x10, 0xaf, 0x93, 0xa9,
+	0xd9, 0x3f, 0x3a, 0x3a, 0x15, 0xd3, 0x7c, 0xf2, 0xca, 0x42, 0x65, 0xf1, 0xe7, 0x19, 0x52, 0xb9,
+	0xdf, 0xd4, 0x1e, 0xa1, 0xca, 0x9f, 0x03, 0x00, 0x69, 0xac, 0xa2, 0x8b, 0xcc, 0x0f, 0x00, 0x00,
 }
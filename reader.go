@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"time"
 
 	gproto "github.com/golang/protobuf/proto"
 
@@ -20,6 +21,9 @@ var (
 
 const (
 	maxPostScriptSize = 256
+	// DefaultStreamBufferSize is the default size of the read-ahead buffer used
+	// by each stream that is read.
+	DefaultStreamBufferSize = 64 * 1024
 )
 
 type SizedReaderAt interface {
@@ -35,11 +39,103 @@ type Reader struct {
 	currentStripeOffset      int
 	currentStripeInformation *proto.StripeInformation
 	schema                   *TypeDescription
+	trimCharPadding          bool
+	positionalEvolution      bool
+	location                 *time.Location
+	streamBufferSize         int
+	concurrency              int
+	unordered                bool
 }
 
-func NewReader(r SizedReaderAt) (*Reader, error) {
+// ReaderConfigFunc is a function that configures a Reader.
+type ReaderConfigFunc func(r *Reader) error
+
+// SetTrimCharPadding determines whether the trailing spaces that pad char
+// column values to their maximum length are removed when reading.
+func SetTrimCharPadding(trim bool) ReaderConfigFunc {
+	return func(r *Reader) error {
+		r.trimCharPadding = trim
+		return nil
+	}
+}
+
+// SetPositionalEvolution determines how the fields of a reader schema passed to
+// SelectWithSchema are matched to the columns of the file. If positional is
+// true, the fields of the root struct are matched by position rather than by
+// name, as is the case by default for files written by Hive with columns named
+// _col0, _col1 and so on.
+func SetPositionalEvolution(positional bool) ReaderConfigFunc {
+	return func(r *Reader) error {
+		r.positionalEvolution = positional
+		return nil
+	}
+}
+
+// SetTimestampLocation sets the location of the timestamps returned by the
+// Reader, which is UTC by default. Timestamps are written relative to the
+// timezone of the writer, so are returned as the same instant in the location.
+func SetTimestampLocation(loc *time.Location) ReaderConfigFunc {
+	return func(r *Reader) error {
+		if loc == nil {
+			return fmt.Errorf("invalid timestamp location: nil")
+		}
+		r.location = loc
+		return nil
+	}
+}
+
+// SetStreamBufferSize sets the size in bytes of the read-ahead buffer used by
+// each stream that is read. Streams are read and decompressed one chunk at a
+// time, so the memory used by a Cursor is bounded by the number of streams of
+// its selected columns multiplied by the buffer size plus the compressed and
+// decompressed size of a chunk.
+func SetStreamBufferSize(size int) ReaderConfigFunc {
+	return func(r *Reader) error {
+		if size <= 0 {
+			return fmt.Errorf("invalid stream buffer size %v", size)
+		}
+		r.streamBufferSize = size
+		return nil
+	}
+}
+
+// SetConcurrency sets the number of goroutines that read and decode stripes
+// ahead of each Cursor. Each goroutine decodes the rows of a single stripe at a
+// time into batches of DefaultBatchSize rows, with at most 4 batches queued
+// until they are consumed by Next and NextBatch, so the rows held in memory are
+// bounded by n rather than by the size of the stripes. Stripes are decoded on
+// the goroutine calling Stripes and Next if n is 0, the default.
+func SetConcurrency(n int) ReaderConfigFunc {
+	return func(r *Reader) error {
+		if n < 0 {
+			return fmt.Errorf("invalid concurrency %v", n)
+		}
+		r.concurrency = n
+		return nil
+	}
+}
+
+// SetOrdered determines whether stripes decoded concurrently are returned by
+// Stripes in file order, the default, or as soon as each has been prepared.
+func SetOrdered(ordered bool) ReaderConfigFunc {
+	return func(r *Reader) error {
+		r.unordered = !ordered
+		return nil
+	}
+}
+
+// NewReader returns a new ORC file reader that reads from the provided SizedReaderAt.
+func NewReader(r SizedReaderAt, fns ...ReaderConfigFunc) (*Reader, error) {
 	reader := &Reader{
-		r: r,
+		r:                r,
+		streamBufferSize: DefaultStreamBufferSize,
+	}
+	// Apply any ReaderConfigFuncs to the new reader.
+	for _, fn := range fns {
+		err := fn(reader)
+		if err != nil {
+			return nil, err
+		}
 	}
 	err := reader.extractMetaInfoFromFooter()
 	if err != nil {
@@ -60,6 +156,12 @@ func (r *Reader) getCodec() (CompressionCodec, error) {
 		return CompressionZlib{}, nil
 	case proto.CompressionKind_SNAPPY:
 		return CompressionSnappy{}, nil
+	case proto.CompressionKind_LZ4:
+		return CompressionLZ4{}, nil
+	case proto.CompressionKind_ZSTD:
+		return CompressionZstd{}, nil
+	case proto.CompressionKind_LZO:
+		return CompressionLZO{}, nil
 	default:
 		return nil, fmt.Errorf("unsupported compression kind %s", compressionKind)
 	}
@@ -169,7 +271,9 @@ func (r *Reader) extractMetaInfoFromFooter() error {
 
 }
 
-func (r *Reader) getStripe(stripeNum int, included ...int) (*Stripe, error) {
+// getStripe loads the streams of the included columns from the stripe, along
+// with the row indexes of the indexed columns.
+func (r *Reader) getStripe(stripeNum int, included, indexed []int) (*Stripe, error) {
 	stripes, err := r.getStripes()
 	if err != nil {
 		return nil, err
@@ -178,6 +282,7 @@ func (r *Reader) getStripe(stripeNum int, included ...int) (*Stripe, error) {
 		return nil, io.EOF
 	}
 	stripe := NewStripe(stripes[stripeNum], included...)
+	stripe.indexed = indexed
 	err = stripe.FromReader(r)
 	if err != nil {
 		return nil, err
@@ -248,6 +353,8 @@ func (r *Reader) createSchema(types []*proto.Type, rootColumn int) (*TypeDescrip
 		return td, nil
 	case proto.Type_TIMESTAMP:
 		return NewTypeDescription(SetCategory(CategoryTimestamp))
+	case proto.Type_TIMESTAMP_INSTANT:
+		return NewTypeDescription(SetCategory(CategoryTimestampInstant))
 	case proto.Type_DATE:
 		return NewTypeDescription(SetCategory(CategoryDate))
 	case proto.Type_LIST:
@@ -352,8 +459,11 @@ func (r *Reader) NumStripes() (int, error) {
 
 type Stripe struct {
 	included []int
+	indexed  []int
 	*proto.StripeInformation
 	columns map[int]*proto.ColumnEncoding
+	// writerTimezone is the timezone of the timestamps of the stripe.
+	writerTimezone *time.Location
 	streamMap
 }
 
@@ -399,6 +509,11 @@ func (s *Stripe) unmarshalStripeFooter(r *Reader) error {
 		return err
 	}
 
+	s.writerTimezone, err = loadTimezone(stripeFooter.GetWriterTimezone())
+	if err != nil {
+		return err
+	}
+
 	// Store the columns and their encoding types so that we can access them later.
 	columns := stripeFooter.GetColumns()
 	for i, column := range columns {
@@ -412,7 +527,8 @@ func (s *Stripe) unmarshalStripeFooter(r *Reader) error {
 		return io.EOF
 	}
 
-	// Iterate through the streams and allocate byte buffers for each.
+	// Iterate through the streams and record the location of each.
+	fileSize := r.r.Size()
 	for _, stream := range streamsProto {
 		// Get the columnID for the stream
 		columnID := int(stream.GetColumn())
@@ -425,28 +541,33 @@ func (s *Stripe) unmarshalStripeFooter(r *Reader) error {
 				include = true
 			}
 		}
-		// Only allocate buffers for columns that we are planning to read.
-		if include {
-			// Create a new section reader for the length of the stream.
-			streamReader := io.NewSectionReader(r.r, streamOffset, streamLength)
-			// Retrieve the codec
-			codec, err := r.getCodec()
-			if err != nil {
-				return err
+		// The row indexes of indexed columns are loaded even if the
+		// column is not included.
+		if !include && stream.GetKind() == proto.Stream_ROW_INDEX {
+			for i := range s.indexed {
+				if s.indexed[i] == columnID {
+					include = true
+				}
 			}
-			dec := codec.Decoder(streamReader)
-			// Copy the stream into a buffer.
-			var streamBuf bytes.Buffer
-			_, err = io.Copy(&streamBuf, dec)
-			if err != nil {
-				return err
-			}
-			// Store the byte buffer within the streamMap using a streamName.
+		}
+		// Only record the streams of columns that we are planning to read.
+		if include {
 			name := streamName{
 				columnID: int(stream.GetColumn()),
 				kind:     stream.GetKind(),
 			}
-			s.streamMap.set(name, &streamBuf)
+			if streamOffset+streamLength > fileSize {
+				return fmt.Errorf("stream %v exceeds the length of the file", name)
+			}
+			// Store the location of the stream within the streamMap using a
+			// streamName, the stream is read lazily as it is consumed.
+			s.streamMap.set(name, &streamSection{
+				r:          r.r,
+				offset:     streamOffset,
+				length:     streamLength,
+				codec:      codec,
+				bufferSize: r.streamBufferSize,
+			})
 		}
 		// Increment the streamOffset for the next stream.
 		streamOffset += streamLength
@@ -461,3 +582,24 @@ func (s *Stripe) getColumn(columnID int) (*proto.ColumnEncoding, error) {
 	}
 	return s.columns[columnID], nil
 }
+
+// getRowIndex returns the row index of the column with the provided ID.
+func (s *Stripe) getRowIndex(columnID int) (*proto.RowIndex, error) {
+	stream := s.get(streamName{
+		columnID: columnID,
+		kind:     proto.Stream_ROW_INDEX,
+	})
+	if stream == nil {
+		return nil, fmt.Errorf("no row index for column: %v", columnID)
+	}
+	byt, err := ioutil.ReadAll(stream)
+	if err != nil {
+		return nil, err
+	}
+	var rowIndex proto.RowIndex
+	err = gproto.Unmarshal(byt, &rowIndex)
+	if err != nil {
+		return nil, err
+	}
+	return &rowIndex, nil
+}
@@ -1,6 +1,8 @@
 package orc
 
 import (
+	"os"
+	"reflect"
 	"testing"
 )
 
@@ -57,3 +59,77 @@ func TestNumStripes(t *testing.T) {
 		t.Fatalf("Expected %d stripes, got %d", expectedStripes, n)
 	}
 }
+
+type countingReaderAt struct {
+	SizedReaderAt
+	read int64
+}
+
+func (c *countingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	n, err := c.SizedReaderAt.ReadAt(p, off)
+	c.read += int64(n)
+	return n, err
+}
+
+func TestReaderStreamBufferSize(t *testing.T) {
+	f, err := os.Open("examples/TestOrcFile.testSeek.orc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	// Read every row using the default stream buffer size.
+	r, err := NewReader(fileReader{f})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cols := r.Schema().Columns()
+	var expected [][]interface{}
+	c := r.Select(cols...)
+	for c.Stripes() {
+		for c.Next() {
+			expected = append(expected, c.Row())
+		}
+	}
+	if err := c.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	cr := &countingReaderAt{SizedReaderAt: fileReader{f}}
+	r, err = NewReader(cr, SetStreamBufferSize(16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c = r.Select(cols...)
+	var rows int
+	for c.Stripes() {
+		// Streams are read as they are consumed rather than when the stripe
+		// is loaded.
+		cr.read = 0
+		if !c.Next() {
+			t.Fatalf("Test failed, expected true, got false: %v", c.Err())
+		}
+		if dataLength := int64(c.Stripe.GetDataLength()); cr.read >= dataLength {
+			t.Errorf("Test failed, expected fewer than %v bytes to be read, got %v", dataLength, cr.read)
+		}
+		for {
+			if !reflect.DeepEqual(c.Row(), expected[rows]) {
+				t.Fatalf("Test failed for row %v, expected %v, got %v", rows, expected[rows], c.Row())
+			}
+			rows++
+			if !c.Next() {
+				break
+			}
+		}
+	}
+	if err := c.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if rows != len(expected) {
+		t.Errorf("Test failed, expected %v rows, got %v", len(expected), rows)
+	}
+
+	if _, err := NewReader(fileReader{f}, SetStreamBufferSize(0)); err == nil {
+		t.Errorf("Test failed, expected an error for an invalid stream buffer size")
+	}
+}
@@ -90,6 +90,29 @@ func (b *RunLengthByteReader) readValues() error {
 	return nil
 }
 
+// seek moves the reader to the position of the underlying stream provided by p,
+// then skips the number of values that follows it.
+func (b *RunLengthByteReader) seek(p *positionProvider) error {
+	if err := seekReader(b.r, p); err != nil {
+		return err
+	}
+	b.nextByte = nil
+	b.numLiterals = 0
+	b.used = 0
+	b.err = nil
+	skip, err := p.next()
+	if err != nil {
+		return err
+	}
+	for ; skip > 0; skip-- {
+		if !b.Next() {
+			return b.err
+		}
+		b.Byte()
+	}
+	return nil
+}
+
 func (b *RunLengthByteReader) Value() interface{} {
 	return int8(b.Byte())
 }
@@ -114,6 +114,38 @@ func (b *RunLengthByteWriter) WriteByte(value byte) error {
 	return nil
 }
 
+// WriteBytes writes each of the values in turn. Values that extend the current
+// run of repeated values are counted without being written individually.
+func (b *RunLengthByteWriter) WriteBytes(values []byte) error {
+	for i := 0; i < len(values); {
+		if b.repeat && values[i] == b.literals[0] {
+			n := 1
+			for i+n < len(values) && n < b.maxRepeatSize-b.numLiterals && values[i+n] == b.literals[0] {
+				n++
+			}
+			b.numLiterals += n
+			i += n
+			if b.numLiterals == b.maxRepeatSize {
+				if err := b.writeValues(); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if err := b.WriteByte(values[i]); err != nil {
+			return err
+		}
+		i++
+	}
+	return nil
+}
+
+// Positions returns the position of the underlying stream followed by the
+// number of values buffered for the next run.
+func (b *RunLengthByteWriter) Positions() []uint64 {
+	return append(streamPositions(b.ByteWriter), uint64(b.numLiterals))
+}
+
 func (b *RunLengthByteWriter) Close() error {
 	return b.Flush()
 }
@@ -84,3 +84,44 @@ func TestWriteReadBytes(t *testing.T) {
 		index++
 	}
 }
+
+func TestRunLengthByteWriterWriteBytes(t *testing.T) {
+	var input []byte
+	for i := 0; i < 100000; i++ {
+		if i/1000%2 == 0 {
+			input = append(input, byte(i/300))
+		} else {
+			input = append(input, uint8(rand.Intn(256)))
+		}
+	}
+	var each, bulk bytes.Buffer
+	w := NewRunLengthByteWriter(&each)
+	for i := range input {
+		err := w.WriteByte(input[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w = NewRunLengthByteWriter(&bulk)
+	for i := 0; i < len(input); i += 777 {
+		end := i + 777
+		if end > len(input) {
+			end = len(input)
+		}
+		err := w.WriteBytes(input[i:end])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(each.Bytes(), bulk.Bytes()) {
+		t.Errorf("Test failed, WriteBytes output differs from WriteByte output")
+	}
+}
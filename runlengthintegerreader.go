@@ -113,6 +113,29 @@ func (r *RunLengthIntegerReader) Int() int64 {
 	return result
 }
 
+// seek moves the reader to the position of the underlying stream provided by p,
+// then skips the number of values that follows it.
+func (r *RunLengthIntegerReader) seek(p *positionProvider) error {
+	if err := seekReader(r.r, p); err != nil {
+		return err
+	}
+	r.nextByte = nil
+	r.numLiterals = 0
+	r.used = 0
+	r.err = nil
+	skip, err := p.next()
+	if err != nil {
+		return err
+	}
+	for ; skip > 0; skip-- {
+		if !r.Next() {
+			return r.err
+		}
+		r.Int()
+	}
+	return nil
+}
+
 func (r *RunLengthIntegerReader) Value() interface{} {
 	return r.Int()
 }
@@ -83,6 +83,32 @@ func (r *RunLengthIntegerReaderV2) Next() bool {
 	return r.used != r.numLiterals || r.available() == nil
 }
 
+// seek moves the reader to the position of the underlying stream provided by p,
+// then skips the number of values that follows it.
+func (r *RunLengthIntegerReaderV2) seek(p *positionProvider) error {
+	if err := seekReader(r.r, p); err != nil {
+		return err
+	}
+	r.nextByte = nil
+	r.numLiterals = 0
+	r.used = 0
+	r.err = nil
+	skip, err := p.next()
+	if err != nil {
+		return err
+	}
+	for ; skip > 0; skip-- {
+		if !r.Next() {
+			return r.err
+		}
+		r.Int()
+		if r.err != nil {
+			return r.err
+		}
+	}
+	return nil
+}
+
 func (r *RunLengthIntegerReaderV2) Value() interface{} {
 	return r.Int()
 }
@@ -154,6 +154,39 @@ func (w *RunLengthIntegerWriter) WriteInt(value int64) error {
 	return nil
 }
 
+// WriteInts writes each of the values in turn. Values that extend the current
+// run of values with a fixed delta are counted without being written
+// individually.
+func (w *RunLengthIntegerWriter) WriteInts(values []int64) error {
+	for i := 0; i < len(values); {
+		if w.repeat && values[i] == w.literals[0]+int64(w.delta*w.numLiterals) {
+			n := 1
+			for i+n < len(values) && n < w.maxRepeatSize-w.numLiterals && values[i+n] == w.literals[0]+int64(w.delta*(w.numLiterals+n)) {
+				n++
+			}
+			w.numLiterals += n
+			i += n
+			if w.numLiterals == w.maxRepeatSize {
+				if err := w.writeValues(); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if err := w.WriteInt(values[i]); err != nil {
+			return err
+		}
+		i++
+	}
+	return nil
+}
+
+// Positions returns the position of the underlying stream followed by the
+// number of values buffered for the next run.
+func (w *RunLengthIntegerWriter) Positions() []uint64 {
+	return append(streamPositions(w.w), uint64(w.numLiterals))
+}
+
 func (w *RunLengthIntegerWriter) Close() error {
 	return w.Flush()
 }
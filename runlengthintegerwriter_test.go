@@ -105,3 +105,47 @@ func TestWriteReadRunLengthIntegerWriterRun(t *testing.T) {
 		index++
 	}
 }
+
+func TestRunLengthIntegerWriterWriteInts(t *testing.T) {
+	var input []int64
+	for i := 0; i < 100000; i++ {
+		switch i / 1000 % 3 {
+		case 0:
+			input = append(input, int64(i/200))
+		case 1:
+			input = append(input, int64(i*3))
+		default:
+			input = append(input, rand.Int63n(1000000))
+		}
+	}
+	var each, bulk bytes.Buffer
+	w := NewRunLengthIntegerWriter(&each, true)
+	for i := range input {
+		err := w.WriteInt(input[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w = NewRunLengthIntegerWriter(&bulk, true)
+	for i := 0; i < len(input); i += 777 {
+		end := i + 777
+		if end > len(input) {
+			end = len(input)
+		}
+		err := w.WriteInts(input[i:end])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(each.Bytes(), bulk.Bytes()) {
+		t.Errorf("Test failed, WriteInts output differs from WriteInt output")
+	}
+}
@@ -240,6 +240,22 @@ func (i *RunLengthIntegerWriterV2) writeValues() error {
 	return nil
 }
 
+// WriteInts writes each of the values in turn.
+func (i *RunLengthIntegerWriterV2) WriteInts(values []int64) error {
+	for _, val := range values {
+		if err := i.WriteInt(val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Positions returns the position of the underlying stream followed by the
+// number of values buffered for the next run.
+func (i *RunLengthIntegerWriterV2) Positions() []uint64 {
+	return append(streamPositions(i.w), uint64(i.numLiterals))
+}
+
 func (i *RunLengthIntegerWriterV2) Close() error {
 	return i.Flush()
 }
@@ -172,3 +172,38 @@ func TestWriteReadRunLengthIntegerWriterV2Run(t *testing.T) {
 		index++
 	}
 }
+
+func TestRunLengthIntegerWriterV2WriteInts(t *testing.T) {
+	var input []int64
+	for i := 0; i < 100000; i++ {
+		if i/1000%2 == 0 {
+			input = append(input, int64(i/200))
+		} else {
+			input = append(input, rand.Int63n(1000000))
+		}
+	}
+	var each, bulk bytes.Buffer
+	w := NewRunLengthIntegerWriterV2(&each, true)
+	for i := range input {
+		err := w.WriteInt(input[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w = NewRunLengthIntegerWriterV2(&bulk, true)
+	err = w.WriteInts(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(each.Bytes(), bulk.Bytes()) {
+		t.Errorf("Test failed, WriteInts output differs from WriteInt output")
+	}
+}
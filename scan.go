@@ -0,0 +1,310 @@
+package orc
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	scannerType    = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	timeType       = reflect.TypeOf(time.Time{})
+	structFieldMap sync.Map
+)
+
+// ScanStruct assigns the values of the current row to the fields of the struct
+// pointed to by dst. Each column is assigned to the field with an orc tag
+// matching the name it was selected by, for example `orc:"int1"`, or otherwise
+// to the field whose name matches it ignoring case. Fields tagged `orc:"-"` and
+// columns without a matching field are ignored.
+//
+// Values are converted to the type of the field where possible: integers to
+// any integer type they fit in, Decimal to a string or float, Date to a
+// time.Time, Struct to a struct whose fields are matched in the same way,
+// lists to slices, []MapEntry to maps and unions to the type of their value.
+// Null values can only be assigned to pointer, slice, map and interface fields
+// or fields that implement sql.Scanner, such as sql.NullInt64. An error is
+// returned if a value cannot be assigned to its field.
+func (c *Cursor) ScanStruct(dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("expected a non-nil pointer to a struct, got %T", dst)
+	}
+	if len(c.nextVal) != len(c.fields) {
+		return fmt.Errorf("no row available to scan")
+	}
+	st := v.Elem()
+	fields := cachedStructFields(st.Type())
+	for i, value := range c.nextVal {
+		name := c.fields[i]
+		field, ok := fields.lookup(name)
+		if !ok {
+			continue
+		}
+		if err := assignValue(st.Field(field.index), value); err != nil {
+			return fmt.Errorf("cannot scan column %s into field %s: %v", name, field.name, err)
+		}
+	}
+	return nil
+}
+
+// structField is an exported field of a struct that values can be scanned into.
+type structField struct {
+	name   string
+	tag    string
+	typ    string
+	index  int
+	tagged bool
+	t      reflect.Type
+}
+
+// columnName returns the name of the column the field is written to.
+func (f structField) columnName() string {
+	if f.tagged {
+		return f.tag
+	}
+	return f.name
+}
+
+type structFields []structField
+
+// lookup returns the field with a tag matching name, or otherwise the untagged
+// field whose name matches it ignoring case.
+func (s structFields) lookup(name string) (structField, bool) {
+	for _, field := range s {
+		if field.tagged && field.tag == name {
+			return field, true
+		}
+	}
+	for _, field := range s {
+		if !field.tagged && strings.EqualFold(field.name, name) {
+			return field, true
+		}
+	}
+	return structField{}, false
+}
+
+// cachedStructFields returns the fields of the struct type t that values can be
+// scanned into.
+func cachedStructFields(t reflect.Type) structFields {
+	if fields, ok := structFieldMap.Load(t); ok {
+		return fields.(structFields)
+	}
+	var fields structFields
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			// The field is unexported.
+			continue
+		}
+		tag := f.Tag.Get("orc")
+		if tag == "-" {
+			continue
+		}
+		// The type of the column may follow the name after the first comma,
+		// for example `orc:"price,decimal(10,2)"`.
+		var typ string
+		if n := strings.IndexByte(tag, ','); n >= 0 {
+			tag, typ = tag[:n], tag[n+1:]
+		}
+		fields = append(fields, structField{
+			name:   f.Name,
+			tag:    tag,
+			typ:    typ,
+			index:  i,
+			tagged: tag != "",
+			t:      f.Type,
+		})
+	}
+	structFieldMap.Store(t, fields)
+	return fields
+}
+
+// assignValue assigns a value returned by a TreeReader to dst, converting it to
+// the type of dst.
+func assignValue(dst reflect.Value, value interface{}) error {
+	if dst.CanAddr() && dst.Addr().Type().Implements(scannerType) {
+		return dst.Addr().Interface().(sql.Scanner).Scan(driverValue(value))
+	}
+	if value == nil {
+		switch dst.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		return fmt.Errorf("cannot assign null to %s", dst.Type())
+	}
+	src := reflect.ValueOf(value)
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+		return nil
+	}
+	if dst.Kind() == reflect.Ptr {
+		elem := reflect.New(dst.Type().Elem())
+		if err := assignValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	}
+	switch t := value.(type) {
+	case UnionValue:
+		return assignValue(dst, t.Value)
+	case Date:
+		return assignValue(dst, t.Time)
+	case int8:
+		return assignInt(dst, int64(t))
+	case int64:
+		return assignInt(dst, t)
+	case Float:
+		return assignFloat(dst, float64(t))
+	case Double:
+		return assignFloat(dst, float64(t))
+	case Decimal:
+		switch dst.Kind() {
+		case reflect.String:
+			dst.SetString(t.String())
+			return nil
+		case reflect.Float32, reflect.Float64:
+			return assignFloat(dst, t.Float64())
+		}
+	case string:
+		switch {
+		case dst.Kind() == reflect.String:
+			dst.SetString(t)
+			return nil
+		case dst.Kind() == reflect.Slice && dst.Type().Elem().Kind() == reflect.Uint8:
+			dst.SetBytes([]byte(t))
+			return nil
+		}
+	case []byte:
+		switch {
+		case dst.Kind() == reflect.String:
+			dst.SetString(string(t))
+			return nil
+		case dst.Kind() == reflect.Slice && dst.Type().Elem().Kind() == reflect.Uint8:
+			dst.SetBytes(append([]byte(nil), t...))
+			return nil
+		}
+	case bool:
+		if dst.Kind() == reflect.Bool {
+			dst.SetBool(t)
+			return nil
+		}
+	case time.Time:
+		if dst.Kind() == reflect.Struct && timeType.ConvertibleTo(dst.Type()) {
+			dst.Set(reflect.ValueOf(t).Convert(dst.Type()))
+			return nil
+		}
+	case Struct:
+		if dst.Kind() == reflect.Struct {
+			return assignStruct(dst, t)
+		}
+	case []interface{}:
+		if dst.Kind() == reflect.Slice {
+			s := reflect.MakeSlice(dst.Type(), len(t), len(t))
+			for i, element := range t {
+				if err := assignValue(s.Index(i), element); err != nil {
+					return err
+				}
+			}
+			dst.Set(s)
+			return nil
+		}
+	case []MapEntry:
+		if dst.Kind() == reflect.Map {
+			m := reflect.MakeMapWithSize(dst.Type(), len(t))
+			for _, entry := range t {
+				key := reflect.New(dst.Type().Key()).Elem()
+				if err := assignValue(key, entry.Key); err != nil {
+					return err
+				}
+				elem := reflect.New(dst.Type().Elem()).Elem()
+				if err := assignValue(elem, entry.Value); err != nil {
+					return err
+				}
+				m.SetMapIndex(key, elem)
+			}
+			dst.Set(m)
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot convert %T to %s", value, dst.Type())
+}
+
+// assignInt assigns the integer value to dst, returning an error if it
+// overflows its type.
+func assignInt(dst reflect.Value, value int64) error {
+	switch dst.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if dst.OverflowInt(value) {
+			return fmt.Errorf("value %v overflows %s", value, dst.Type())
+		}
+		dst.SetInt(value)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if value < 0 || dst.OverflowUint(uint64(value)) {
+			return fmt.Errorf("value %v overflows %s", value, dst.Type())
+		}
+		dst.SetUint(uint64(value))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		dst.SetFloat(float64(value))
+		return nil
+	}
+	return fmt.Errorf("cannot convert integer to %s", dst.Type())
+}
+
+// assignFloat assigns the floating point value to dst, returning an error if it
+// overflows its type.
+func assignFloat(dst reflect.Value, value float64) error {
+	switch dst.Kind() {
+	case reflect.Float32, reflect.Float64:
+		if !math.IsInf(value, 0) && dst.OverflowFloat(value) {
+			return fmt.Errorf("value %v overflows %s", value, dst.Type())
+		}
+		dst.SetFloat(value)
+		return nil
+	}
+	return fmt.Errorf("cannot convert floating point value to %s", dst.Type())
+}
+
+// assignStruct assigns the values of a struct column to the fields of dst that
+// match their names.
+func assignStruct(dst reflect.Value, value Struct) error {
+	fields := cachedStructFields(dst.Type())
+	for name, v := range value {
+		field, ok := fields.lookup(name)
+		if !ok {
+			continue
+		}
+		if err := assignValue(dst.Field(field.index), v); err != nil {
+			return fmt.Errorf("field %s: %v", field.name, err)
+		}
+	}
+	return nil
+}
+
+// driverValue converts a value returned by a TreeReader to one of the types
+// accepted by the Scan method of the types in database/sql.
+func driverValue(value interface{}) interface{} {
+	switch t := value.(type) {
+	case int8:
+		return int64(t)
+	case Float:
+		return float64(t)
+	case Double:
+		return float64(t)
+	case Date:
+		return t.Time
+	case Decimal:
+		return t.String()
+	case UnionValue:
+		return driverValue(t.Value)
+	}
+	return value
+}
@@ -0,0 +1,142 @@
+package orc
+
+import (
+	"bytes"
+	"database/sql"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type scanPoint struct {
+	X int `orc:"x"`
+	Y float32
+}
+
+type scanRow struct {
+	ID      int32            `orc:"id"`
+	Big     sql.NullInt64    `orc:"big"`
+	Small   int              `orc:"small"`
+	Price   string           `orc:"price"`
+	Day     time.Time        `orc:"day"`
+	Name    *string          `orc:"name"`
+	Point   scanPoint        `orc:"point"`
+	Tags    map[string]int64 `orc:"tags"`
+	Nums    []int16          `orc:"nums"`
+	Ignored string           `orc:"-"`
+}
+
+func TestCursorScanStruct(t *testing.T) {
+	schema, err := ParseSchema("struct<id:int,big:bigint,small:tinyint,price:decimal(10,2),day:date,name:string,point:struct<x:int,y:double>,tags:map<string,int>,nums:array<int>>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, SetSchema(schema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	day := time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC)
+	err = w.Write(
+		int64(1),
+		int64(1)<<40,
+		byte(7),
+		NewDecimal(big.NewInt(1234), 2),
+		day,
+		"one",
+		[]interface{}{int64(3), Double(0.5)},
+		map[string]int64{"a": 1, "b": 2},
+		[]int64{4, 5},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = w.Write(int64(2), nil, byte(8), nil, day, nil, []interface{}{int64(4), nil}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(&bytesSizedReaderAt{bytes.NewBuffer(buf.Bytes())})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := r.Select(schema.Columns()...)
+	if !c.Stripes() || !c.Next() {
+		t.Fatalf("Test failed, expected a row: %v", c.Err())
+	}
+
+	got := scanRow{Ignored: "unchanged"}
+	if err := c.ScanStruct(&got); err != nil {
+		t.Fatal(err)
+	}
+	name := "one"
+	expected := scanRow{
+		ID:      1,
+		Big:     sql.NullInt64{Int64: 1 << 40, Valid: true},
+		Small:   7,
+		Price:   "12.34",
+		Day:     day,
+		Name:    &name,
+		Point:   scanPoint{X: 3, Y: 0.5},
+		Tags:    map[string]int64{"a": 1, "b": 2},
+		Nums:    []int16{4, 5},
+		Ignored: "unchanged",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Test failed, expected %+v got %+v", expected, got)
+	}
+
+	// Nulls can be assigned to pointers, maps, slices and sql.Scanner fields.
+	if !c.Next() {
+		t.Fatalf("Test failed, expected a row: %v", c.Err())
+	}
+	var nulls struct {
+		ID    int64         `orc:"id"`
+		Big   sql.NullInt64 `orc:"big"`
+		Price *string       `orc:"price"`
+		Name  *string       `orc:"name"`
+		Point struct {
+			Y *float64 `orc:"y"`
+		} `orc:"point"`
+		Tags map[string]int64 `orc:"tags"`
+	}
+	nulls.Name = &name
+	if err := c.ScanStruct(&nulls); err != nil {
+		t.Fatal(err)
+	}
+	if nulls.ID != 2 || nulls.Big.Valid || nulls.Price != nil || nulls.Name != nil || nulls.Point.Y != nil || nulls.Tags != nil {
+		t.Errorf("Test failed, expected null values got %+v", nulls)
+	}
+
+	// Values that cannot be assigned to their field return an error.
+	var null struct {
+		Name string `orc:"name"`
+	}
+	if err := c.ScanStruct(&null); err == nil {
+		t.Errorf("Test failed, expected an error scanning null into a string")
+	}
+	var wrongType struct {
+		ID string `orc:"id"`
+	}
+	if err := c.ScanStruct(&wrongType); err == nil {
+		t.Errorf("Test failed, expected an error scanning an integer into a string")
+	}
+	var overflow struct {
+		Big int8 `orc:"big"`
+	}
+	c.Close()
+	c = r.Select(schema.Columns()...)
+	if !c.Stripes() || !c.Next() {
+		t.Fatalf("Test failed, expected a row: %v", c.Err())
+	}
+	if err := c.ScanStruct(&overflow); err == nil {
+		t.Errorf("Test failed, expected an error scanning a bigint that overflows an int8")
+	}
+	if err := c.ScanStruct(got); err == nil {
+		t.Errorf("Test failed, expected an error scanning into a struct value")
+	}
+}
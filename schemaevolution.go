@@ -0,0 +1,437 @@
+package orc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/scritchley/orc/proto"
+)
+
+// SelectWithSchema returns a Cursor that reads the fields of readerSchema, which
+// must be a struct, from the file. See Cursor.SelectWithSchema.
+func (r *Reader) SelectWithSchema(readerSchema *TypeDescription) *Cursor {
+	cursor := &Cursor{Reader: r}
+	return cursor.SelectWithSchema(readerSchema)
+}
+
+// SelectWithSchema determines the columns that will be read from the ORC file
+// using a reader schema, which must be a struct whose fields are returned as the
+// columns of each row. The reader schema is mapped to the schema of the file so
+// that files written with earlier versions of a schema can be read with the
+// current one:
+//
+//   - Struct fields are matched to the fields of the file by name, ignoring
+//     case. The fields of the root struct are matched by position instead if
+//     the Reader was configured with SetPositionalEvolution or the file was
+//     written by Hive with fields named _col0, _col1 and so on.
+//   - Fields that are not in the file are returned as null, and fields of the
+//     file that are not in the reader schema are not read.
+//   - Values are converted to the type of the reader schema if it is wider:
+//     tinyint, smallint and int to a larger integer type, integers to float or
+//     double, float to double and decimals to another scale. Any primitive type
+//     can be read as a string, varchar or char, which are truncated to their
+//     maximum length.
+//
+// An error is returned by Err if a column cannot be converted.
+func (c *Cursor) SelectWithSchema(readerSchema *TypeDescription) *Cursor {
+	c.closePipeline()
+	if readerSchema == nil || readerSchema.category != CategoryStruct {
+		c.err = fmt.Errorf("reader schema must be a struct, got %v", readerSchema)
+		return c
+	}
+	fileSchema := c.Reader.schema
+	if fileSchema.category != CategoryStruct {
+		c.err = fmt.Errorf("cannot read file schema %s as %s", fileSchema, readerSchema)
+		return c
+	}
+	positional := c.Reader.positionalEvolution || hasHiveFieldNames(fileSchema)
+	var evolution []*schemaEvolution
+	var included []int
+	for i, column := range readerSchema.children {
+		var file *TypeDescription
+		if positional {
+			if i < len(fileSchema.children) {
+				file = fileSchema.children[i]
+			}
+		} else {
+			file = fileSchema.field(readerSchema.fieldNames[i])
+		}
+		e, err := newSchemaEvolution(file, column)
+		if err != nil {
+			c.err = fmt.Errorf("cannot read column %s: %v", readerSchema.fieldNames[i], err)
+			return c
+		}
+		evolution = append(evolution, e)
+		included = append(included, e.included()...)
+	}
+	c.columns = readerSchema.children
+	c.included = included
+	c.fields = readerSchema.fieldNames
+	c.evolution = evolution
+	return c
+}
+
+// hasHiveFieldNames returns true if the fields of the struct are named _col0,
+// _col1 and so on, as written by Hive before it recorded the names of columns.
+func hasHiveFieldNames(td *TypeDescription) bool {
+	for i, name := range td.fieldNames {
+		if name != "_col"+strconv.Itoa(i) {
+			return false
+		}
+	}
+	return len(td.fieldNames) > 0
+}
+
+// field returns the child of the struct with the provided name, ignoring case as
+// ParseSchema converts names to lower case, or nil if it has no such field.
+func (t *TypeDescription) field(name string) *TypeDescription {
+	for i, fieldName := range t.fieldNames {
+		if strings.EqualFold(fieldName, name) {
+			return t.children[i]
+		}
+	}
+	return nil
+}
+
+// schemaEvolution maps a column of the reader schema to the column of the file
+// schema that it is read from.
+type schemaEvolution struct {
+	reader *TypeDescription
+	// file is nil if the column is not in the file.
+	file *TypeDescription
+	// convert is true if the values of a primitive column are converted to the
+	// type of the reader schema.
+	convert bool
+	// children maps the children of a compound column.
+	children []*schemaEvolution
+}
+
+// newSchemaEvolution maps the column of the reader schema to the column of the
+// file, returning an error if its values cannot be converted.
+func newSchemaEvolution(file, reader *TypeDescription) (*schemaEvolution, error) {
+	e := &schemaEvolution{
+		reader: reader,
+		file:   file,
+	}
+	if file == nil {
+		return e, nil
+	}
+	if reader.category.isPrimitive {
+		if !file.category.isPrimitive || !canConvert(file, reader) {
+			return nil, fmt.Errorf("cannot convert %s to %s", file, reader)
+		}
+		e.convert = needsConversion(file, reader)
+		return e, nil
+	}
+	if file.category != reader.category {
+		return nil, fmt.Errorf("cannot convert %s to %s", file, reader)
+	}
+	switch reader.category {
+	case CategoryStruct:
+		for i, child := range reader.children {
+			ce, err := newSchemaEvolution(file.field(reader.fieldNames[i]), child)
+			if err != nil {
+				return nil, err
+			}
+			e.children = append(e.children, ce)
+		}
+	default:
+		// The children of lists, maps and unions are matched by position.
+		if len(file.children) != len(reader.children) {
+			return nil, fmt.Errorf("cannot convert %s to %s", file, reader)
+		}
+		for i, child := range reader.children {
+			ce, err := newSchemaEvolution(file.children[i], child)
+			if err != nil {
+				return nil, err
+			}
+			e.children = append(e.children, ce)
+		}
+	}
+	return e, nil
+}
+
+// included returns the IDs of the columns of the file that are read.
+func (e *schemaEvolution) included() []int {
+	if e.file == nil {
+		return nil
+	}
+	included := []int{e.file.getID()}
+	for _, child := range e.children {
+		included = append(included, child.included()...)
+	}
+	return included
+}
+
+// evolvedField returns the mapping of the field of the reader schema with the
+// provided name, where the fields of nested structs are separated by dots, or
+// nil if the reader schema has no such field.
+func evolvedField(evolution []*schemaEvolution, fieldNames []string, name string) *schemaEvolution {
+	names := strings.SplitN(name, ".", 2)
+	for i, fieldName := range fieldNames {
+		if fieldName != names[0] || i >= len(evolution) {
+			continue
+		}
+		e := evolution[i]
+		if len(names) == 1 {
+			return e
+		}
+		if e.reader.category != CategoryStruct {
+			return nil
+		}
+		return evolvedField(e.children, e.reader.fieldNames, names[1])
+	}
+	return nil
+}
+
+// statisticsColumn returns the column of the file whose statistics can be
+// compared with values of the reader schema, or nil if the column is not in
+// the file or its values are converted to a type its statistics do not
+// describe. Integers widened to a larger integer type can still be compared.
+func (e *schemaEvolution) statisticsColumn() *TypeDescription {
+	if e.file == nil {
+		return nil
+	}
+	if e.convert {
+		_, fileInteger := integerRanks[e.file.category]
+		_, readerInteger := integerRanks[e.reader.category]
+		if !fileInteger || !readerInteger {
+			return nil
+		}
+	}
+	return e.file
+}
+
+// integerRanks orders the integer categories by width.
+var integerRanks = map[Category]int{
+	CategoryByte:  1,
+	CategoryShort: 2,
+	CategoryInt:   3,
+	CategoryLong:  4,
+}
+
+// canConvert returns true if the values of the primitive file column can be
+// read as the primitive type of the reader schema.
+func canConvert(file, reader *TypeDescription) bool {
+	switch reader.category {
+	case CategoryString, CategoryVarchar, CategoryChar:
+		return true
+	case file.category:
+		return true
+	case CategoryShort, CategoryInt, CategoryLong:
+		rank, ok := integerRanks[file.category]
+		return ok && rank < integerRanks[reader.category]
+	case CategoryFloat:
+		_, ok := integerRanks[file.category]
+		return ok
+	case CategoryDouble:
+		_, ok := integerRanks[file.category]
+		return ok || file.category == CategoryFloat
+	default:
+		return false
+	}
+}
+
+// needsConversion returns true if the values of the file column are not
+// returned as values of the reader schema type without conversion.
+func needsConversion(file, reader *TypeDescription) bool {
+	switch reader.category {
+	case CategoryString:
+		switch file.category {
+		case CategoryString, CategoryVarchar, CategoryChar:
+			return false
+		}
+		return true
+	case CategoryVarchar, CategoryChar:
+		switch file.category {
+		case CategoryString:
+			return true
+		case CategoryVarchar, CategoryChar:
+			return file.maxLength > reader.maxLength
+		}
+		return true
+	case CategoryDecimal:
+		return file.scale != reader.scale
+	default:
+		return file.category != reader.category
+	}
+}
+
+// convertValue converts a value of a file column to the type of the reader
+// schema. Values are only converted as allowed by canConvert.
+func convertValue(value interface{}, reader *TypeDescription) interface{} {
+	switch reader.category {
+	case CategoryShort, CategoryInt, CategoryLong:
+		if i, ok := value.(int8); ok {
+			return int64(i)
+		}
+	case CategoryFloat:
+		switch t := value.(type) {
+		case int8:
+			return Float(t)
+		case int64:
+			return Float(t)
+		}
+	case CategoryDouble:
+		switch t := value.(type) {
+		case int8:
+			return Double(t)
+		case int64:
+			return Double(t)
+		case Float:
+			return Double(t)
+		}
+	case CategoryDecimal:
+		if d, ok := value.(Decimal); ok {
+			return d.Rescale(int64(reader.scale))
+		}
+	case CategoryString, CategoryVarchar, CategoryChar:
+		s := formatValue(value)
+		if reader.category != CategoryString && utf8.RuneCountInString(s) > reader.maxLength {
+			s = string([]rune(s)[:reader.maxLength])
+		}
+		return s
+	}
+	return value
+}
+
+// formatValue returns the value of a primitive column as a string.
+func formatValue(value interface{}) string {
+	switch t := value.(type) {
+	case string:
+		return t
+	case []byte:
+		return string(t)
+	case bool:
+		// Booleans are formatted as by the Java implementation of ORC.
+		if t {
+			return "TRUE"
+		}
+		return "FALSE"
+	case int8:
+		return strconv.FormatInt(int64(t), 10)
+	case int64:
+		return strconv.FormatInt(t, 10)
+	case Float:
+		return strconv.FormatFloat(float64(t), 'g', -1, 32)
+	case Double:
+		return strconv.FormatFloat(float64(t), 'g', -1, 64)
+	case Date:
+		return t.Format("2006-01-02")
+	case time.Time:
+		return t.Format("2006-01-02 15:04:05.999999999")
+	default:
+		return fmt.Sprint(t)
+	}
+}
+
+// createEvolvedTreeReader returns the TreeReader for the column of the reader
+// schema, adding the TreeReaders of the columns of the file that it reads to
+// readers by column ID.
+func createEvolvedTreeReader(e *schemaEvolution, s *Stripe, readers map[int]TreeReader, trimCharPadding bool, location *time.Location) (TreeReader, error) {
+	if e.file == nil {
+		return nullTreeReader{}, nil
+	}
+	if e.reader.category.isPrimitive {
+		reader, err := createTreeReader(e.file, s, readers, trimCharPadding, location)
+		if err != nil || !e.convert {
+			return reader, err
+		}
+		return &convertTreeReader{reader, e.reader}, nil
+	}
+	id := e.file.getID()
+	encoding, err := s.getColumn(id)
+	if err != nil {
+		return nil, err
+	}
+	children := make([]TreeReader, len(e.children))
+	for i, child := range e.children {
+		children[i], err = createEvolvedTreeReader(child, s, readers, trimCharPadding, location)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var reader TreeReader
+	switch e.reader.category {
+	case CategoryList:
+		reader, err = NewListTreeReader(
+			s.get(streamName{id, proto.Stream_PRESENT}),
+			s.get(streamName{id, proto.Stream_LENGTH}),
+			children[0],
+			encoding,
+		)
+	case CategoryMap:
+		reader, err = NewMapTreeReader(
+			s.get(streamName{id, proto.Stream_PRESENT}),
+			s.get(streamName{id, proto.Stream_LENGTH}),
+			children[0],
+			children[1],
+			encoding,
+		)
+	case CategoryStruct:
+		fields := make(map[string]TreeReader)
+		for i, child := range children {
+			fields[e.reader.fieldNames[i]] = child
+		}
+		reader, err = NewStructTreeReader(
+			s.get(streamName{id, proto.Stream_PRESENT}),
+			fields,
+		)
+	case CategoryUnion:
+		reader, err = NewUnionTreeReader(
+			s.get(streamName{id, proto.Stream_PRESENT}),
+			s.get(streamName{id, proto.Stream_DATA}),
+			children,
+		)
+	default:
+		return nil, fmt.Errorf("unsupported type: %s", e.reader.category)
+	}
+	if err != nil {
+		return nil, err
+	}
+	readers[id] = reader
+	return reader, nil
+}
+
+// convertTreeReader is a TreeReader that converts the values of a column of the
+// file to the type of the reader schema.
+type convertTreeReader struct {
+	TreeReader
+	reader *TypeDescription
+}
+
+// Value implements the TreeReader interface.
+func (c *convertTreeReader) Value() interface{} {
+	value := c.TreeReader.Value()
+	if value == nil {
+		return nil
+	}
+	return convertValue(value, c.reader)
+}
+
+// nullTreeReader is a TreeReader for a column of the reader schema that is not
+// in the file, whose values are all null.
+type nullTreeReader struct{}
+
+// Next implements the TreeReader interface.
+func (nullTreeReader) Next() bool {
+	return true
+}
+
+// Value implements the TreeReader interface.
+func (nullTreeReader) Value() interface{} {
+	return nil
+}
+
+// Err implements the TreeReader interface.
+func (nullTreeReader) Err() error {
+	return nil
+}
+
+// IsPresent returns false as all values are null.
+func (nullTreeReader) IsPresent() bool {
+	return false
+}
@@ -0,0 +1,237 @@
+package orc
+
+import (
+	"bytes"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+// writeEvolutionFile writes rows with the provided schema to a new file and
+// returns its contents.
+func writeEvolutionFile(t *testing.T, schema string, rows ...[]interface{}) []byte {
+	td, err := ParseSchema(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := &bytes.Buffer{}
+	w, err := NewWriter(buf, SetSchema(td))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range rows {
+		if err := w.Write(row...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// readEvolutionRows reads every row of the file using the reader schema.
+func readEvolutionRows(t *testing.T, file []byte, schema string, fns ...ReaderConfigFunc) [][]interface{} {
+	td, err := ParseSchema(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewReader(&bytesSizedReaderAt{bytes.NewBuffer(file)}, fns...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := r.SelectWithSchema(td)
+	var rows [][]interface{}
+	for c.Stripes() {
+		for c.Next() {
+			rows = append(rows, c.Row())
+		}
+	}
+	if err := c.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return rows
+}
+
+func TestSelectWithSchema(t *testing.T) {
+	file := writeEvolutionFile(t,
+		"struct<id:int,name:string,score:float,flag:tinyint,price:decimal(10,2),nested:struct<a:int,b:string>,tags:array<smallint>>",
+		[]interface{}{int64(1), "alice", float32(1.5), int8(-3), NewDecimal(big.NewInt(1250), 2), []interface{}{int64(7), "x"}, []interface{}{int64(1), int64(2)}},
+		[]interface{}{int64(2), nil, nil, nil, nil, nil, nil},
+	)
+
+	schema := "struct<tags:array<bigint>,missing:string,nested:struct<b:string,c:int,a:bigint>,ID:bigint,score:double,flag:string,name:varchar(3),price:decimal(12,3)>"
+	expected := [][]interface{}{
+		{
+			[]interface{}{int64(1), int64(2)},
+			nil,
+			Struct{"b": "x", "c": nil, "a": int64(7)},
+			int64(1),
+			Double(1.5),
+			"-3",
+			"ali",
+			NewDecimal(big.NewInt(12500), 3),
+		},
+		{nil, nil, nil, int64(2), nil, nil, nil, nil},
+	}
+	for _, concurrency := range []int{0, 2} {
+		rows := readEvolutionRows(t, file, schema, SetConcurrency(concurrency))
+		if !reflect.DeepEqual(rows, expected) {
+			t.Errorf("Test failed with concurrency %v, expected %v got %v", concurrency, expected, rows)
+		}
+	}
+
+	// Rows read in batches are converted in the same way.
+	td, err := ParseSchema("struct<missing:int,flag:string,id:bigint,score:double>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewReader(&bytesSizedReaderAt{bytes.NewBuffer(file)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := r.SelectWithSchema(td)
+	b := NewBatch(10)
+	if !c.Stripes() || !c.NextBatch(b) {
+		t.Fatalf("Test failed, expected a batch: %v", c.Err())
+	}
+	if b.Len != 2 || !b.Columns[0].IsNull(0) || !b.Columns[0].IsNull(1) {
+		t.Errorf("Test failed, expected 2 rows of nulls got %v rows", b.Len)
+	}
+	if got := string(b.Columns[1].(*BytesVector).Bytes(0)); got != "-3" || !b.Columns[1].IsNull(1) {
+		t.Errorf("Test failed, expected -3 got %s", got)
+	}
+	if got := b.Columns[2].(*LongVector).Values; !reflect.DeepEqual(got, []int64{1, 2}) {
+		t.Errorf("Test failed, expected [1 2] got %v", got)
+	}
+	if got := b.Columns[3].(*DoubleVector).Values[0]; got != 1.5 {
+		t.Errorf("Test failed, expected 1.5 got %v", got)
+	}
+
+	// Columns are scanned into structs by the names of the reader schema.
+	c = r.SelectWithSchema(td)
+	var dst struct {
+		ID   int64
+		Flag string
+	}
+	if !c.Stripes() || !c.Next() {
+		t.Fatalf("Test failed, expected a row: %v", c.Err())
+	}
+	if err := c.ScanStruct(&dst); err != nil {
+		t.Fatal(err)
+	}
+	if dst.ID != 1 || dst.Flag != "-3" {
+		t.Errorf("Test failed, expected {1 -3} got %+v", dst)
+	}
+
+	for _, schema := range []string{
+		"struct<id:smallint>",
+		"struct<name:int>",
+		"struct<nested:array<int>>",
+		"struct<nested:struct<a:string,b:int>>",
+	} {
+		td, err := ParseSchema(schema)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := r.SelectWithSchema(td).Err(); err == nil {
+			t.Errorf("Test failed, expected an error reading with schema %s", schema)
+		}
+	}
+	td, err = NewTypeDescription(SetCategory(CategoryInt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.SelectWithSchema(td).Err(); err == nil {
+		t.Errorf("Test failed, expected an error for a reader schema that is not a struct")
+	}
+}
+
+func TestSelectWithSchemaPositional(t *testing.T) {
+	// Hive wrote files with columns named _col0, _col1 and so on.
+	file := writeEvolutionFile(t, "struct<_col0:int,_col1:string>",
+		[]interface{}{int64(1), "a"},
+	)
+	rows := readEvolutionRows(t, file, "struct<id:bigint,name:string,added:int>")
+	expected := [][]interface{}{{int64(1), "a", nil}}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("Test failed, expected %v got %v", expected, rows)
+	}
+
+	file = writeEvolutionFile(t, "struct<a:int,b:string>",
+		[]interface{}{int64(1), "a"},
+	)
+	rows = readEvolutionRows(t, file, "struct<b:int,a:string>", SetPositionalEvolution(true))
+	expected = [][]interface{}{{int64(1), "a"}}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("Test failed, expected %v got %v", expected, rows)
+	}
+	rows = readEvolutionRows(t, file, "struct<b:string,a:bigint>")
+	expected = [][]interface{}{{"a", int64(1)}}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("Test failed, expected %v got %v", expected, rows)
+	}
+}
+
+func TestSelectWithSchemaWhere(t *testing.T) {
+	testCases := []struct {
+		schema     string
+		predicate  Predicate
+		positional bool
+		rows       int
+	}{
+		// Fields are matched by name and integers widened to bigint can
+		// still be compared with the statistics of the file.
+		{"struct<int1:bigint>", Eq("int1", 2), false, 5000},
+		// Fields renamed using positional evolution are resolved through
+		// the reader schema.
+		{"struct<id:int,name:string>", Eq("id", 2), true, 5000},
+		{"struct<id:int,name:string>", Eq("name", "three"), true, 1000},
+		// The statistics of columns read as another type are not used.
+		{"struct<int1:string>", Eq("int1", "2"), false, 11000},
+	}
+
+	for _, tc := range testCases {
+		var fns []ReaderConfigFunc
+		if tc.positional {
+			fns = append(fns, SetPositionalEvolution(true))
+		}
+		r, err := Open("./examples/TestOrcFile.testStripeLevelStats.orc", fns...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		td, err := ParseSchema(tc.schema)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := r.SelectWithSchema(td).Where(tc.predicate)
+		var rows int
+		for c.Stripes() {
+			for c.Next() {
+				rows++
+			}
+		}
+		if err := c.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if rows != tc.rows {
+			t.Errorf("Test failed for %s, expected %v rows got %v", tc.schema, tc.rows, rows)
+		}
+		r.Close()
+	}
+
+	r, err := Open("./examples/TestOrcFile.testStripeLevelStats.orc", SetPositionalEvolution(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	td, err := ParseSchema("struct<id:int,name:string>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Predicates refer to the fields of the reader schema, not the file.
+	c := r.SelectWithSchema(td).Where(Eq("int1", 2))
+	if c.Stripes() || c.Err() == nil {
+		t.Errorf("Test failed, expected an error for a field that is not in the reader schema")
+	}
+}
@@ -0,0 +1,493 @@
+package orc
+
+import (
+	"math/big"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/scritchley/orc/proto"
+)
+
+// Predicate is a condition on the values of one or more columns. Predicates are
+// evaluated against the column statistics of sections of an ORC file so that
+// sections containing no rows that can satisfy the predicate are skipped.
+type Predicate interface {
+	// mightMatch returns false if none of the rows summarised by the statistics
+	// returned by lookup can satisfy the predicate.
+	mightMatch(lookup statisticsLookup) bool
+	// negate returns the negation of the predicate.
+	negate() Predicate
+	// columns returns the names of the columns referenced by the predicate.
+	columns() []string
+}
+
+// statisticsLookup returns the statistics and type of the named column, or false
+// if no statistics are available for the column.
+type statisticsLookup func(column string) (*proto.ColumnStatistics, *TypeDescription, bool)
+
+// And returns a Predicate that is satisfied when all of the predicates are satisfied.
+func And(predicates ...Predicate) Predicate {
+	return andPredicate(predicates)
+}
+
+// Or returns a Predicate that is satisfied when any of the predicates are satisfied.
+func Or(predicates ...Predicate) Predicate {
+	return orPredicate(predicates)
+}
+
+// Not returns a Predicate that is satisfied when the predicate is not satisfied.
+// As in SQL, null values satisfy neither a comparison nor its negation.
+func Not(predicate Predicate) Predicate {
+	return predicate.negate()
+}
+
+// Eq returns a Predicate that is satisfied when the column is equal to value.
+func Eq(column string, value interface{}) Predicate {
+	return &leafPredicate{column, operatorEquals, []interface{}{value}}
+}
+
+// NotEq returns a Predicate that is satisfied when the column is not equal to value.
+func NotEq(column string, value interface{}) Predicate {
+	return &leafPredicate{column, operatorNotEquals, []interface{}{value}}
+}
+
+// Lt returns a Predicate that is satisfied when the column is less than value.
+func Lt(column string, value interface{}) Predicate {
+	return &leafPredicate{column, operatorLessThan, []interface{}{value}}
+}
+
+// Le returns a Predicate that is satisfied when the column is less than or equal to value.
+func Le(column string, value interface{}) Predicate {
+	return &leafPredicate{column, operatorLessThanEquals, []interface{}{value}}
+}
+
+// Gt returns a Predicate that is satisfied when the column is greater than value.
+func Gt(column string, value interface{}) Predicate {
+	return &leafPredicate{column, operatorGreaterThan, []interface{}{value}}
+}
+
+// Ge returns a Predicate that is satisfied when the column is greater than or equal to value.
+func Ge(column string, value interface{}) Predicate {
+	return &leafPredicate{column, operatorGreaterThanEquals, []interface{}{value}}
+}
+
+// In returns a Predicate that is satisfied when the column is equal to any of the values.
+func In(column string, values ...interface{}) Predicate {
+	return &leafPredicate{column, operatorIn, values}
+}
+
+// Between returns a Predicate that is satisfied when the column is greater than
+// or equal to lower and less than or equal to upper.
+func Between(column string, lower, upper interface{}) Predicate {
+	return And(Ge(column, lower), Le(column, upper))
+}
+
+// IsNull returns a Predicate that is satisfied when the column is null.
+func IsNull(column string) Predicate {
+	return &leafPredicate{column, operatorIsNull, nil}
+}
+
+// IsNotNull returns a Predicate that is satisfied when the column is not null.
+func IsNotNull(column string) Predicate {
+	return &leafPredicate{column, operatorIsNotNull, nil}
+}
+
+type andPredicate []Predicate
+
+func (a andPredicate) mightMatch(lookup statisticsLookup) bool {
+	for _, p := range a {
+		if !p.mightMatch(lookup) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a andPredicate) negate() Predicate {
+	negated := make(orPredicate, len(a))
+	for i, p := range a {
+		negated[i] = p.negate()
+	}
+	return negated
+}
+
+func (a andPredicate) columns() []string {
+	var columns []string
+	for _, p := range a {
+		columns = append(columns, p.columns()...)
+	}
+	return columns
+}
+
+type orPredicate []Predicate
+
+func (o orPredicate) mightMatch(lookup statisticsLookup) bool {
+	for _, p := range o {
+		if p.mightMatch(lookup) {
+			return true
+		}
+	}
+	return len(o) == 0
+}
+
+func (o orPredicate) negate() Predicate {
+	negated := make(andPredicate, len(o))
+	for i, p := range o {
+		negated[i] = p.negate()
+	}
+	return negated
+}
+
+func (o orPredicate) columns() []string {
+	return andPredicate(o).columns()
+}
+
+type predicateOperator int
+
+const (
+	operatorEquals predicateOperator = iota
+	operatorNotEquals
+	operatorLessThan
+	operatorLessThanEquals
+	operatorGreaterThan
+	operatorGreaterThanEquals
+	operatorIn
+	operatorNotIn
+	operatorIsNull
+	operatorIsNotNull
+)
+
+// negatedOperators maps each operator to the operator of its negation.
+var negatedOperators = map[predicateOperator]predicateOperator{
+	operatorEquals:            operatorNotEquals,
+	operatorNotEquals:         operatorEquals,
+	operatorLessThan:          operatorGreaterThanEquals,
+	operatorLessThanEquals:    operatorGreaterThan,
+	operatorGreaterThan:       operatorLessThanEquals,
+	operatorGreaterThanEquals: operatorLessThan,
+	operatorIn:                operatorNotIn,
+	operatorNotIn:             operatorIn,
+	operatorIsNull:            operatorIsNotNull,
+	operatorIsNotNull:         operatorIsNull,
+}
+
+// leafPredicate is a Predicate that compares a single column with literal values.
+type leafPredicate struct {
+	column   string
+	operator predicateOperator
+	values   []interface{}
+}
+
+func (l *leafPredicate) negate() Predicate {
+	return &leafPredicate{l.column, negatedOperators[l.operator], l.values}
+}
+
+func (l *leafPredicate) columns() []string {
+	return []string{l.column}
+}
+
+func (l *leafPredicate) mightMatch(lookup statisticsLookup) bool {
+	stats, td, ok := lookup(l.column)
+	if !ok || stats == nil {
+		return true
+	}
+	switch l.operator {
+	case operatorIsNull:
+		return stats.HasNull == nil || stats.GetHasNull()
+	case operatorIsNotNull:
+		return stats.NumberOfValues == nil || stats.GetNumberOfValues() > 0
+	}
+	// Only null values satisfy none of the remaining operators.
+	if stats.NumberOfValues != nil && stats.GetNumberOfValues() == 0 {
+		return false
+	}
+	min, max, ok := statisticsRange(stats, td)
+	if !ok {
+		return true
+	}
+	for _, value := range l.values {
+		literal, ok := predicateLiteral(td, value)
+		if !ok {
+			return true
+		}
+		lower, ok := compareValues(min, literal)
+		if !ok {
+			return true
+		}
+		upper, ok := compareValues(max, literal)
+		if !ok {
+			return true
+		}
+		var match bool
+		switch l.operator {
+		case operatorEquals, operatorIn:
+			match = lower <= 0 && upper >= 0
+		case operatorNotEquals, operatorNotIn:
+			match = lower != 0 || upper != 0
+		case operatorLessThan:
+			match = lower < 0
+		case operatorLessThanEquals:
+			match = lower <= 0
+		case operatorGreaterThan:
+			match = upper > 0
+		case operatorGreaterThanEquals:
+			match = upper >= 0
+		}
+		switch l.operator {
+		case operatorNotIn:
+			// Every value must be excluded for a row to match.
+			if !match {
+				return false
+			}
+		default:
+			if match {
+				return true
+			}
+		}
+	}
+	return l.operator == operatorNotIn
+}
+
+// statisticsRange returns the minimum and maximum values recorded by the column
+// statistics, or false if they are not available. Values are returned in the
+// representation used for comparison by compareValues.
+func statisticsRange(stats *proto.ColumnStatistics, td *TypeDescription) (interface{}, interface{}, bool) {
+	switch {
+	case stats.IntStatistics != nil:
+		s := stats.IntStatistics
+		if s.Minimum == nil || s.Maximum == nil {
+			return nil, nil, false
+		}
+		return s.GetMinimum(), s.GetMaximum(), true
+	case stats.DoubleStatistics != nil:
+		s := stats.DoubleStatistics
+		if s.Minimum == nil || s.Maximum == nil {
+			return nil, nil, false
+		}
+		return s.GetMinimum(), s.GetMaximum(), true
+	case stats.StringStatistics != nil:
+		s := stats.StringStatistics
+		if s.Minimum == nil || s.Maximum == nil {
+			return nil, nil, false
+		}
+		return s.GetMinimum(), s.GetMaximum(), true
+	case stats.DecimalStatistics != nil:
+		s := stats.DecimalStatistics
+		min, ok := new(big.Rat).SetString(s.GetMinimum())
+		if !ok {
+			return nil, nil, false
+		}
+		max, ok := new(big.Rat).SetString(s.GetMaximum())
+		if !ok {
+			return nil, nil, false
+		}
+		return min, max, true
+	case stats.DateStatistics != nil:
+		s := stats.DateStatistics
+		if s.Minimum == nil || s.Maximum == nil {
+			return nil, nil, false
+		}
+		return int64(s.GetMinimum()), int64(s.GetMaximum()), true
+	case stats.TimestampStatistics != nil:
+		// Only the UTC values can be compared, as the other values are recorded
+		// in the unknown local time of the writer by older writers.
+		s := stats.TimestampStatistics
+		if s.MinimumUtc == nil || s.MaximumUtc == nil {
+			return nil, nil, false
+		}
+		min := timeFromMillis(s.GetMinimumUtc())
+		// The statistics have millisecond precision, so the maximum may be
+		// followed by up to a millisecond of nanoseconds.
+		max := timeFromMillis(s.GetMaximumUtc()).Add(time.Millisecond - time.Nanosecond)
+		return min, max, true
+	case stats.BucketStatistics != nil && td.category == CategoryBoolean:
+		s := stats.BucketStatistics
+		if len(s.Count) == 0 || stats.NumberOfValues == nil {
+			return nil, nil, false
+		}
+		trueCount := s.Count[0]
+		return trueCount >= stats.GetNumberOfValues(), trueCount > 0, true
+	default:
+		return nil, nil, false
+	}
+}
+
+// predicateLiteral converts the literal value of a predicate to the
+// representation used for comparison with statistics of the column type td, or
+// returns false if the value cannot be converted.
+func predicateLiteral(td *TypeDescription, value interface{}) (interface{}, bool) {
+	switch td.category {
+	case CategoryByte, CategoryShort, CategoryInt, CategoryLong, CategoryFloat, CategoryDouble:
+		if i, ok := literalInt64(value); ok {
+			return i, true
+		}
+		if f, ok := literalFloat64(value); ok {
+			return f, true
+		}
+	case CategoryString, CategoryVarchar:
+		if s, ok := value.(string); ok {
+			return s, true
+		}
+	case CategoryChar:
+		if s, ok := value.(string); ok {
+			// Char values are padded with spaces to the maximum length.
+			if n := utf8.RuneCountInString(s); n < td.maxLength {
+				s += strings.Repeat(" ", td.maxLength-n)
+			}
+			return s, true
+		}
+	case CategoryDecimal:
+		switch t := value.(type) {
+		case Decimal:
+			if t.Int != nil {
+				return t.Rat(), true
+			}
+		case *big.Rat:
+			return t, true
+		case big.Rat:
+			return &t, true
+		case string:
+			return new(big.Rat).SetString(t)
+		}
+		if i, ok := literalInt64(value); ok {
+			return new(big.Rat).SetInt64(i), true
+		}
+		if f, ok := literalFloat64(value); ok {
+			r := new(big.Rat).SetFloat64(f)
+			return r, r != nil
+		}
+	case CategoryDate:
+		switch t := value.(type) {
+		case time.Time:
+			return daysSinceEpoch(t), true
+		case Date:
+			return daysSinceEpoch(t.Time), true
+		}
+	case CategoryTimestamp, CategoryTimestampInstant:
+		if t, ok := value.(time.Time); ok {
+			return t, true
+		}
+	case CategoryBoolean:
+		if b, ok := value.(bool); ok {
+			return b, true
+		}
+	}
+	return nil, false
+}
+
+func literalInt64(value interface{}) (int64, bool) {
+	switch t := value.(type) {
+	case int:
+		return int64(t), true
+	case int8:
+		return int64(t), true
+	case int16:
+		return int64(t), true
+	case int32:
+		return int64(t), true
+	case int64:
+		return t, true
+	case uint8:
+		return int64(t), true
+	case uint16:
+		return int64(t), true
+	case uint32:
+		return int64(t), true
+	default:
+		return 0, false
+	}
+}
+
+func literalFloat64(value interface{}) (float64, bool) {
+	switch t := value.(type) {
+	case float32:
+		return float64(t), true
+	case Float:
+		return float64(t), true
+	case float64:
+		return t, true
+	case Double:
+		return float64(t), true
+	default:
+		return 0, false
+	}
+}
+
+// compareValues compares a and b, returning -1, 0 or +1 if a is less than, equal
+// to or greater than b respectively. It returns false if the values cannot be
+// compared.
+func compareValues(a, b interface{}) (int, bool) {
+	switch a := a.(type) {
+	case int64:
+		switch b := b.(type) {
+		case int64:
+			return compareInt64(a, b), true
+		case float64:
+			return compareFloat64(float64(a), b)
+		}
+	case float64:
+		switch b := b.(type) {
+		case float64:
+			return compareFloat64(a, b)
+		case int64:
+			return compareFloat64(a, float64(b))
+		}
+	case string:
+		if b, ok := b.(string); ok {
+			return strings.Compare(a, b), true
+		}
+	case *big.Rat:
+		if b, ok := b.(*big.Rat); ok {
+			return a.Cmp(b), true
+		}
+	case time.Time:
+		if b, ok := b.(time.Time); ok {
+			switch {
+			case a.Before(b):
+				return -1, true
+			case a.After(b):
+				return 1, true
+			default:
+				return 0, true
+			}
+		}
+	case bool:
+		if b, ok := b.(bool); ok {
+			switch {
+			case a == b:
+				return 0, true
+			case b:
+				return -1, true
+			default:
+				return 1, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareFloat64(a, b float64) (int, bool) {
+	switch {
+	case a < b:
+		return -1, true
+	case a > b:
+		return 1, true
+	case a == b:
+		return 0, true
+	default:
+		// One of the values is NaN.
+		return 0, false
+	}
+}
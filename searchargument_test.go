@@ -0,0 +1,451 @@
+package orc
+
+import (
+	"bytes"
+	"math/big"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/scritchley/orc/proto"
+)
+
+func TestPredicateMightMatch(t *testing.T) {
+	schema, err := ParseSchema("struct<int1:int,string1:string,double1:double,decimal1:decimal(10,2),ts:timestamp,bool1:boolean,char1:char(4)>")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	stats := map[string]*proto.ColumnStatistics{
+		"int1": {
+			NumberOfValues: ptrUint64(10),
+			HasNull:        gprotoBool(false),
+			IntStatistics:  &proto.IntegerStatistics{Minimum: ptrInt64(10), Maximum: ptrInt64(20)},
+		},
+		"string1": {
+			NumberOfValues:   ptrUint64(10),
+			HasNull:          gprotoBool(true),
+			StringStatistics: &proto.StringStatistics{Minimum: ptrStr("b"), Maximum: ptrStr("d")},
+		},
+		"double1": {
+			NumberOfValues:   ptrUint64(10),
+			DoubleStatistics: &proto.DoubleStatistics{Minimum: gprotoFloat64(1.5), Maximum: gprotoFloat64(2.5)},
+		},
+		"decimal1": {
+			NumberOfValues:    ptrUint64(10),
+			DecimalStatistics: &proto.DecimalStatistics{Minimum: ptrStr("1.25"), Maximum: ptrStr("3.5")},
+		},
+		"ts": {
+			NumberOfValues: ptrUint64(10),
+			TimestampStatistics: &proto.TimestampStatistics{
+				MinimumUtc: ptrInt64(timestampMillis(ts)),
+				MaximumUtc: ptrInt64(timestampMillis(ts.Add(time.Hour))),
+			},
+		},
+		"bool1": {
+			NumberOfValues:   ptrUint64(10),
+			BucketStatistics: &proto.BucketStatistics{Count: []uint64{10}},
+		},
+		"char1": {
+			NumberOfValues:   ptrUint64(10),
+			StringStatistics: &proto.StringStatistics{Minimum: ptrStr("ab  "), Maximum: ptrStr("ab  ")},
+		},
+	}
+	lookup := func(column string) (*proto.ColumnStatistics, *TypeDescription, bool) {
+		td, err := schema.GetField(column)
+		if err != nil {
+			return nil, nil, false
+		}
+		s, ok := stats[column]
+		return s, td, ok
+	}
+
+	testCases := []struct {
+		predicate Predicate
+		expected  bool
+	}{
+		{Eq("int1", 15), true},
+		{Eq("int1", 21), false},
+		{Eq("int1", int64(10)), true},
+		{Eq("int1", 9.5), false},
+		{NotEq("int1", 15), true},
+		{Lt("int1", 10), false},
+		{Le("int1", 10), true},
+		{Gt("int1", 20), false},
+		{Ge("int1", 20), true},
+		{In("int1", 1, 2, 3), false},
+		{In("int1", 1, 12), true},
+		{Between("int1", 21, 30), false},
+		{Between("int1", 5, 10), true},
+		{Not(Between("int1", 5, 30)), false},
+		{Not(Eq("int1", 15)), true},
+		{IsNull("int1"), false},
+		{IsNotNull("int1"), true},
+		{IsNull("string1"), true},
+		{Eq("string1", "a"), false},
+		{Eq("string1", "c"), true},
+		{Gt("string1", "d"), false},
+		{Eq("double1", 3.0), false},
+		{Eq("double1", 2), true},
+		{Gt("double1", float32(2.5)), false},
+		{Eq("decimal1", NewDecimal(big.NewInt(350), 2)), true},
+		{Gt("decimal1", NewDecimal(big.NewInt(35), 1)), false},
+		{Lt("decimal1", "1.25"), false},
+		{Gt("ts", ts.Add(2*time.Hour)), false},
+		{Gt("ts", ts.Add(time.Hour)), true},
+		{Lt("ts", ts), false},
+		{Eq("bool1", false), false},
+		{Eq("bool1", true), true},
+		{Eq("char1", "ab"), true},
+		{Eq("char1", "ac"), false},
+		{And(Eq("int1", 15), Eq("string1", "a")), false},
+		{Or(Eq("int1", 15), Eq("string1", "a")), true},
+		{Not(Or(Eq("int1", 25), Eq("string1", "a"))), true},
+		{Eq("missing", 1), true},
+		{Eq("int1", "not an int"), true},
+	}
+
+	for i, tc := range testCases {
+		if got := tc.predicate.mightMatch(lookup); got != tc.expected {
+			t.Errorf("Test case %d failed, expected %v got %v", i, tc.expected, got)
+		}
+	}
+}
+
+func TestCursorWhere(t *testing.T) {
+	r, err := Open("./examples/TestOrcFile.testStripeLevelStats.orc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	testCases := []struct {
+		predicate Predicate
+		rows      int
+	}{
+		{Eq("int1", 2), 5000},
+		{Or(Eq("int1", 1), Eq("string1", "three")), 6000},
+		{Gt("int1", 3), 0},
+		{Not(Eq("int1", 2)), 6000},
+	}
+
+	for _, tc := range testCases {
+		c := r.Select("int1").Where(tc.predicate)
+		var rows int
+		for c.Stripes() {
+			for c.Next() {
+				rows++
+			}
+		}
+		if err := c.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if rows != tc.rows {
+			t.Errorf("Expected %v rows, got %v", tc.rows, rows)
+		}
+	}
+
+	c := r.Select("int1").Where(Eq("missing", 1))
+	if c.Stripes() {
+		t.Errorf("Expected no stripes for a missing column")
+	}
+	if c.Err() == nil {
+		t.Errorf("Expected an error for a missing column")
+	}
+}
+
+func TestCursorWhereTimestamp(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	schema, err := ParseSchema("struct<ts:timestamp,country:string>")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := NewWriter(buf, SetSchema(schema), SetStripeTargetSize(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Each stripe contains a row index stride of rows.
+	start := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	stride := int(DefaultRowIndexStride)
+	countries := []string{"FR", "DE", "GB", "GB", "US"}
+	for i := 0; i < stride*len(countries); i++ {
+		err = w.Write(start.Add(time.Duration(i)*time.Second), countries[i/stride])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(&bytesSizedReaderAt{bytes.NewBuffer(buf.Bytes())})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t0 := start.Add(time.Duration(3*stride) * time.Second)
+	c := r.Select("ts", "country").Where(And(Ge("ts", t0), Eq("country", "GB")))
+	var rows int
+	for c.Stripes() {
+		for c.Next() {
+			row := c.Row()
+			if row[1] != "GB" {
+				t.Errorf("Expected only stripes containing GB, got %v", row[1])
+			}
+			rows++
+		}
+	}
+	if err := c.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if rows != stride {
+		t.Errorf("Expected %v rows, got %v", stride, rows)
+	}
+}
+
+func TestCursorWhereDate(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	schema, err := ParseSchema("struct<d:date>")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := NewWriter(buf, SetSchema(schema), SetStripeTargetSize(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Each stripe contains a row index stride of rows with the same date.
+	start := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	stride := int(DefaultRowIndexStride)
+	for i := 0; i < stride*5; i++ {
+		err = w.Write(start.AddDate(0, 0, i/stride))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(&bytesSizedReaderAt{bytes.NewBuffer(buf.Bytes())})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A Date read from the file can be used as a literal.
+	c := r.Select("d")
+	if err := c.SeekRow(int64(2 * stride)); err != nil {
+		t.Fatal(err)
+	}
+	if !c.Next() {
+		t.Fatal(c.Err())
+	}
+	d, ok := c.Row()[0].(Date)
+	if !ok {
+		t.Fatalf("Expected a Date, got %T", c.Row()[0])
+	}
+
+	c = r.Select("d").Where(Eq("d", d))
+	var rows int
+	for c.Stripes() {
+		for c.Next() {
+			if got := c.Row()[0].(Date); !got.Equal(d.Time) {
+				t.Errorf("Expected only stripes containing %v, got %v", d, got)
+			}
+			rows++
+		}
+	}
+	if err := c.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if rows != stride {
+		t.Errorf("Expected %v rows, got %v", stride, rows)
+	}
+}
+
+func TestCursorWhereRowGroups(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	schema, err := ParseSchema("struct<id:bigint,score:int,country:string>")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := NewWriter(buf, SetSchema(schema), SetCompression(CompressionSnappy{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Each row group contains rows for a single country within one stripe.
+	stride := int(DefaultRowIndexStride)
+	countries := []string{"FR", "DE", "GB", "GB", "US"}
+	for i := 0; i < stride*len(countries); i++ {
+		var score interface{}
+		if i%3 != 0 {
+			score = int64(i % 100)
+		}
+		err = w.Write(int64(i), score, countries[i/stride])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(&bytesSizedReaderAt{bytes.NewBuffer(buf.Bytes())})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	numStripes, err := r.NumStripes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if numStripes != 1 {
+		t.Fatalf("Expected 1 stripe, got %v", numStripes)
+	}
+
+	testCases := []struct {
+		predicate Predicate
+		first     int
+		rows      int
+	}{
+		{Eq("country", "GB"), 2 * stride, 2 * stride},
+		{Eq("country", "US"), 4 * stride, stride},
+		{Lt("id", int64(stride)), 0, stride},
+		{Eq("country", "ES"), 0, 0},
+	}
+
+	for _, tc := range testCases {
+		c := r.Select("id", "score").Where(tc.predicate)
+		var rows int
+		for c.Stripes() {
+			for c.Next() {
+				row := c.Row()
+				i := tc.first + rows
+				if row[0] != int64(i) {
+					t.Fatalf("Expected id %v, got %v", i, row[0])
+				}
+				if i%3 == 0 && row[1] != nil {
+					t.Fatalf("Expected nil score for id %v, got %v", i, row[1])
+				}
+				if i%3 != 0 && row[1] != int64(i%100) {
+					t.Fatalf("Expected score %v for id %v, got %v", i%100, i, row[1])
+				}
+				rows++
+			}
+		}
+		if err := c.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if rows != tc.rows {
+			t.Errorf("Expected %v rows, got %v", tc.rows, rows)
+		}
+	}
+}
+
+func TestCursorWhereRowGroupsPositions(t *testing.T) {
+	r, err := Open("./examples/TestOrcFile.testPredicatePushdown.orc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	// The file contains 3500 rows with int1 set to 300 times the row number in
+	// row groups of 1000 rows.
+	c := r.Select("int1", "string1").Where(Between("int1", 300*1500, 300*1600))
+	var rows int
+	for c.Stripes() {
+		for c.Next() {
+			row := c.Row()
+			if expected := int64(300 * (1000 + rows)); row[0] != expected {
+				t.Fatalf("Expected int1 %v, got %v", expected, row[0])
+			}
+			if expected := strconv.FormatInt(int64(10*(1000+rows)), 16); row[1] != expected {
+				t.Fatalf("Expected string1 %v, got %v", expected, row[1])
+			}
+			rows++
+		}
+	}
+	if err := c.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if rows != 1000 {
+		t.Errorf("Expected 1000 rows, got %v", rows)
+	}
+}
+
+func gprotoBool(b bool) *bool {
+	return &b
+}
+
+func gprotoFloat64(f float64) *float64 {
+	return &f
+}
+
+func TestCursorLoadRowIndexes(t *testing.T) {
+	// The positions of each stream recorded by the Java implementation of ORC
+	// are used to seek.
+	for _, example := range []string{
+		"TestOrcFile.test1.orc",
+		"TestOrcFile.testSeek.orc",
+		"TestOrcFile.testSnappy.orc",
+		"TestOrcFile.testUnionAndTimestamp.orc",
+		"TestVectorOrcFile.testLz4.orc",
+		"decimal.orc",
+		"over1k_bloom.orc",
+	} {
+		r, err := Open("./examples/" + example)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := r.Select(r.Schema().Columns()...)
+		if !c.Stripes() {
+			t.Fatalf("Test failed, expected a stripe in %s: %v", example, c.Err())
+		}
+		rowIndexes, err := c.loadRowIndexes()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rowIndexes == nil {
+			t.Errorf("Test failed, expected the row indexes of %s to be used", example)
+		}
+		r.Close()
+	}
+
+	// The number of positions depends on the streams of the column and whether
+	// they are compressed.
+	for _, test := range []struct {
+		kind       proto.Type_Kind
+		encoding   proto.ColumnEncoding_Kind
+		hasPresent bool
+		compressed bool
+		expected   int
+	}{
+		{proto.Type_INT, proto.ColumnEncoding_DIRECT_V2, false, false, 2},
+		{proto.Type_INT, proto.ColumnEncoding_DIRECT_V2, true, true, 7},
+		{proto.Type_BOOLEAN, proto.ColumnEncoding_DIRECT, false, true, 4},
+		{proto.Type_DOUBLE, proto.ColumnEncoding_DIRECT, false, false, 1},
+		{proto.Type_STRING, proto.ColumnEncoding_DIRECT_V2, false, false, 3},
+		{proto.Type_STRING, proto.ColumnEncoding_DICTIONARY_V2, false, true, 3},
+		{proto.Type_TIMESTAMP, proto.ColumnEncoding_DIRECT_V2, true, false, 7},
+		{proto.Type_STRUCT, proto.ColumnEncoding_DIRECT, false, false, 0},
+	} {
+		if got := numPositions(test.kind, test.encoding, test.hasPresent, test.compressed); got != test.expected {
+			t.Errorf("Test failed for %v, expected %v positions got %v", test.kind, test.expected, got)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package orc
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/scritchley/orc/proto"
+)
+
+// Stats holds the statistics of a column as recorded in a file, stripe or row
+// group. It is one of *IntegerStats, *DoubleStats, *StringStats, *BooleanStats,
+// *DecimalStats, *DateStats, *TimestampStats, *BinaryStats or, for compound
+// columns and columns without statistics of their type, *BaseStats.
+type Stats interface {
+	// Base returns the statistics recorded for columns of every type.
+	Base() BaseStats
+}
+
+// BaseStats holds the statistics recorded for columns of every type.
+type BaseStats struct {
+	// NumberOfValues is the number of values of the column.
+	NumberOfValues uint64
+	// HasNull is true if the column has null values.
+	HasNull bool
+}
+
+// Base returns the BaseStats.
+func (b *BaseStats) Base() BaseStats {
+	return *b
+}
+
+// IntegerStats holds the statistics of tinyint, smallint, int and bigint
+// columns. Min and Max are only set if HasMinMax is true, and Sum is only set
+// if HasSum is true, which is not the case if the sum overflowed.
+type IntegerStats struct {
+	BaseStats
+	Min, Max  int64
+	Sum       int64
+	HasMinMax bool
+	HasSum    bool
+}
+
+// DoubleStats holds the statistics of float and double columns. Min and Max are
+// only set if HasMinMax is true.
+type DoubleStats struct {
+	BaseStats
+	Min, Max  float64
+	Sum       float64
+	HasMinMax bool
+}
+
+// StringStats holds the statistics of string, char and varchar columns. Min and
+// Max are only set if HasMinMax is true. TotalLength is the sum of the lengths
+// of the values in bytes.
+type StringStats struct {
+	BaseStats
+	Min, Max    string
+	TotalLength int64
+	HasMinMax   bool
+}
+
+// BooleanStats holds the statistics of boolean columns.
+type BooleanStats struct {
+	BaseStats
+	// TrueCount is the number of true values.
+	TrueCount uint64
+}
+
+// DecimalStats holds the statistics of decimal columns. Min and Max are only
+// set if HasMinMax is true, and Sum is only set if HasSum is true, which is not
+// the case if the sum exceeded the maximum precision of a decimal.
+type DecimalStats struct {
+	BaseStats
+	Min, Max  Decimal
+	Sum       Decimal
+	HasMinMax bool
+	HasSum    bool
+}
+
+// DateStats holds the statistics of date columns. Min and Max are only set if
+// HasMinMax is true, and are midnight in UTC of the dates.
+type DateStats struct {
+	BaseStats
+	Min, Max  time.Time
+	HasMinMax bool
+}
+
+// TimestampStats holds the statistics of timestamp and timestamp with local time
+// zone columns. Min and Max are only set if HasMinMax is true, and are in UTC
+// with millisecond precision. Values within the millisecond following Max may
+// also have been written.
+type TimestampStats struct {
+	BaseStats
+	Min, Max  time.Time
+	HasMinMax bool
+}
+
+// BinaryStats holds the statistics of binary columns. TotalLength is the sum of
+// the lengths of the values in bytes.
+type BinaryStats struct {
+	BaseStats
+	TotalLength int64
+}
+
+// ColumnStatistics returns the statistics of the column, which may be nested
+// such as "struct1.field1", across all stripes of the file.
+func (r *Reader) ColumnStatistics(column string) (Stats, error) {
+	td, err := r.schema.GetField(column)
+	if err != nil {
+		return nil, err
+	}
+	colStats := r.footer.GetStatistics()
+	if td.getID() >= len(colStats) {
+		return nil, fmt.Errorf("no statistics for column: %s", column)
+	}
+	return newStats(td, colStats[td.getID()])
+}
+
+// StripeStatistics returns the statistics of the column within the stripe with
+// the provided index.
+func (r *Reader) StripeStatistics(stripe int, column string) (Stats, error) {
+	td, err := r.schema.GetField(column)
+	if err != nil {
+		return nil, err
+	}
+	stripeStats := r.metadata.GetStripeStats()
+	if stripe < 0 || stripe >= len(stripeStats) {
+		return nil, fmt.Errorf("no statistics for stripe: %v", stripe)
+	}
+	colStats := stripeStats[stripe].GetColStats()
+	if td.getID() >= len(colStats) {
+		return nil, fmt.Errorf("no statistics for column: %s", column)
+	}
+	return newStats(td, colStats[td.getID()])
+}
+
+// RowGroupStatistics returns the statistics of the column for each row group
+// of the stripe with the provided index, as recorded in its row index. Row
+// groups hold the number of rows given by the row index stride of the file.
+func (r *Reader) RowGroupStatistics(stripe int, column string) ([]Stats, error) {
+	td, err := r.schema.GetField(column)
+	if err != nil {
+		return nil, err
+	}
+	if stripe < 0 || stripe >= len(r.footer.GetStripes()) {
+		return nil, fmt.Errorf("no stripe: %v", stripe)
+	}
+	s, err := r.getStripe(stripe, nil, []int{td.getID()})
+	if err != nil {
+		return nil, err
+	}
+	rowIndex, err := s.getRowIndex(td.getID())
+	if err != nil {
+		return nil, err
+	}
+	stats := make([]Stats, len(rowIndex.GetEntry()))
+	for i, entry := range rowIndex.GetEntry() {
+		stats[i], err = newStats(td, entry.GetStatistics())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return stats, nil
+}
+
+// newStats converts the statistics of the column type td to its Stats.
+func newStats(td *TypeDescription, s *proto.ColumnStatistics) (Stats, error) {
+	base := BaseStats{
+		NumberOfValues: s.GetNumberOfValues(),
+		HasNull:        s.GetHasNull(),
+	}
+	switch td.category {
+	case CategoryByte, CategoryShort, CategoryInt, CategoryLong:
+		is := s.GetIntStatistics()
+		return &IntegerStats{
+			BaseStats: base,
+			Min:       is.GetMinimum(),
+			Max:       is.GetMaximum(),
+			Sum:       is.GetSum(),
+			HasMinMax: is != nil && is.Minimum != nil && is.Maximum != nil,
+			HasSum:    is != nil && is.Sum != nil,
+		}, nil
+	case CategoryFloat, CategoryDouble:
+		ds := s.GetDoubleStatistics()
+		return &DoubleStats{
+			BaseStats: base,
+			Min:       ds.GetMinimum(),
+			Max:       ds.GetMaximum(),
+			Sum:       ds.GetSum(),
+			HasMinMax: ds != nil && ds.Minimum != nil && ds.Maximum != nil,
+		}, nil
+	case CategoryString, CategoryVarchar, CategoryChar:
+		ss := s.GetStringStatistics()
+		return &StringStats{
+			BaseStats:   base,
+			Min:         ss.GetMinimum(),
+			Max:         ss.GetMaximum(),
+			TotalLength: ss.GetSum(),
+			HasMinMax:   ss != nil && ss.Minimum != nil && ss.Maximum != nil,
+		}, nil
+	case CategoryBoolean:
+		stats := &BooleanStats{BaseStats: base}
+		if count := s.GetBucketStatistics().GetCount(); len(count) > 0 {
+			stats.TrueCount = count[0]
+		}
+		return stats, nil
+	case CategoryDecimal:
+		ds := s.GetDecimalStatistics()
+		stats := &DecimalStats{BaseStats: base}
+		if ds == nil {
+			return stats, nil
+		}
+		var err error
+		if ds.Minimum != nil && ds.Maximum != nil {
+			if stats.Min, err = parseDecimal(ds.GetMinimum()); err != nil {
+				return nil, err
+			}
+			if stats.Max, err = parseDecimal(ds.GetMaximum()); err != nil {
+				return nil, err
+			}
+			stats.HasMinMax = true
+		}
+		if ds.Sum != nil {
+			if stats.Sum, err = parseDecimal(ds.GetSum()); err != nil {
+				return nil, err
+			}
+			stats.HasSum = true
+		}
+		return stats, nil
+	case CategoryDate:
+		ds := s.GetDateStatistics()
+		stats := &DateStats{BaseStats: base}
+		if ds != nil && ds.Minimum != nil && ds.Maximum != nil {
+			stats.Min = time.Unix(int64(ds.GetMinimum())*86400, 0).UTC()
+			stats.Max = time.Unix(int64(ds.GetMaximum())*86400, 0).UTC()
+			stats.HasMinMax = true
+		}
+		return stats, nil
+	case CategoryTimestamp, CategoryTimestampInstant:
+		ts := s.GetTimestampStatistics()
+		stats := &TimestampStats{BaseStats: base}
+		switch {
+		case ts == nil:
+		case ts.MinimumUtc != nil && ts.MaximumUtc != nil:
+			stats.Min = timeFromMillis(ts.GetMinimumUtc())
+			stats.Max = timeFromMillis(ts.GetMaximumUtc())
+			stats.HasMinMax = true
+		case ts.Minimum != nil && ts.Maximum != nil:
+			// Older writers only record the values in their local time,
+			// which is unknown, so they are returned as if they were UTC.
+			stats.Min = timeFromMillis(ts.GetMinimum())
+			stats.Max = timeFromMillis(ts.GetMaximum())
+			stats.HasMinMax = true
+		}
+		return stats, nil
+	case CategoryBinary:
+		return &BinaryStats{
+			BaseStats:   base,
+			TotalLength: s.GetBinaryStatistics().GetSum(),
+		}, nil
+	default:
+		return &base, nil
+	}
+}
+
+// parseDecimal parses a decimal as written in decimal statistics, keeping the
+// number of digits following the decimal point as its scale.
+func parseDecimal(s string) (Decimal, error) {
+	digits := s
+	var scale int64
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		digits = s[:i] + s[i+1:]
+		scale = int64(len(s) - i - 1)
+	}
+	i, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		// Fall back to parsing values written in another format, such as
+		// with an exponent, using the smallest scale that represents them.
+		r, ok := new(big.Rat).SetString(s)
+		if !ok {
+			return Decimal{}, fmt.Errorf("invalid decimal statistics value: %q", s)
+		}
+		scale = 0
+		for scale < maxScale && !new(big.Rat).Mul(r, new(big.Rat).SetInt(scaleToDenominator(scale))).IsInt() {
+			scale++
+		}
+		return decimalFromRat(r, scale), nil
+	}
+	return NewDecimal(i, scale), nil
+}
@@ -0,0 +1,153 @@
+package orc
+
+import (
+	"bytes"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReaderColumnStatistics(t *testing.T) {
+	r, err := Open("./examples/TestOrcFile.test1.orc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	expected := map[string]Stats{
+		"boolean1": &BooleanStats{BaseStats{2, false}, 1},
+		"byte1":    &IntegerStats{BaseStats: BaseStats{2, false}, Min: 1, Max: 100, Sum: 101, HasMinMax: true, HasSum: true},
+		// The sum of long1 overflows so is not recorded.
+		"long1":   &IntegerStats{BaseStats: BaseStats{2, false}, Min: 9223372036854775807, Max: 9223372036854775807, HasMinMax: true},
+		"double1": &DoubleStats{BaseStats: BaseStats{2, false}, Min: -15, Max: -5, Sum: -20, HasMinMax: true},
+		"bytes1":  &BinaryStats{BaseStats{2, false}, 5},
+		"string1": &StringStats{BaseStats: BaseStats{2, false}, Min: "bye", Max: "hi", TotalLength: 5, HasMinMax: true},
+		"middle":  &BaseStats{2, false},
+	}
+	for column, stats := range expected {
+		got, err := r.ColumnStatistics(column)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, stats) {
+			t.Errorf("Test failed for %s, expected %+v got %+v", column, stats, got)
+		}
+		if got, err := r.StripeStatistics(0, column); err != nil || !reflect.DeepEqual(got, stats) {
+			t.Errorf("Test failed for %s, expected stripe statistics %+v got %+v: %v", column, stats, got, err)
+		}
+	}
+
+	if _, err := r.ColumnStatistics("missing"); err == nil {
+		t.Errorf("Test failed, expected an error for a missing column")
+	}
+	if _, err := r.StripeStatistics(1, "int1"); err == nil {
+		t.Errorf("Test failed, expected an error for a missing stripe")
+	}
+}
+
+func TestReaderRowGroupStatistics(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	schema, err := ParseSchema("struct<decimal1:decimal(10,2),date1:date,timestamp1:timestamp>")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Write two stripes of 20 rows, each with two row groups.
+	w, err := NewWriter(buf, SetSchema(schema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.footer.RowIndexStride = ptrUint32(10)
+	w.stripeTargetRowCount = 20
+
+	epoch := time.Unix(0, 0).UTC()
+	for i := 0; i < 35; i++ {
+		var timestamp1 interface{}
+		if i >= 10 {
+			timestamp1 = epoch.Add(time.Duration(i) * time.Second)
+		}
+		err := w.Write(NewDecimal(big.NewInt(int64(i*25)), 2), epoch.AddDate(0, 0, i), timestamp1)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(&bytesSizedReaderAt{bytes.NewBuffer(buf.Bytes())})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decimal := func(i int64) Decimal {
+		return NewDecimal(big.NewInt(i), 2)
+	}
+	stats, err := r.ColumnStatistics("decimal1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &DecimalStats{BaseStats: BaseStats{35, false}, Min: decimal(0), Max: decimal(850), Sum: decimal(14875), HasMinMax: true, HasSum: true}
+	if !reflect.DeepEqual(stats, expected) {
+		t.Errorf("Test failed, expected %+v got %+v", expected, stats)
+	}
+
+	stats, err = r.StripeStatistics(1, "date1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected2 := &DateStats{BaseStats: BaseStats{15, false}, Min: epoch.AddDate(0, 0, 20), Max: epoch.AddDate(0, 0, 34), HasMinMax: true}
+	if !reflect.DeepEqual(stats, expected2) {
+		t.Errorf("Test failed, expected %+v got %+v", expected2, stats)
+	}
+
+	rowGroups, err := r.RowGroupStatistics(0, "timestamp1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The number of values does not include nulls.
+	expected3 := []Stats{
+		&TimestampStats{BaseStats: BaseStats{0, true}},
+		&TimestampStats{BaseStats: BaseStats{10, false}, Min: epoch.Add(10 * time.Second), Max: epoch.Add(19 * time.Second), HasMinMax: true},
+	}
+	if !reflect.DeepEqual(rowGroups, expected3) {
+		t.Errorf("Test failed, expected %+v got %+v", expected3, rowGroups)
+	}
+
+	rowGroups, err = r.RowGroupStatistics(1, "date1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rowGroups) != 2 {
+		t.Fatalf("Test failed, expected 2 row groups got %v", len(rowGroups))
+	}
+	if s := rowGroups[1].(*DateStats); !s.Min.Equal(epoch.AddDate(0, 0, 30)) || !s.Max.Equal(epoch.AddDate(0, 0, 34)) {
+		t.Errorf("Test failed, expected the last row group to hold dates 30 to 34 got %+v", s)
+	}
+
+	if _, err := r.RowGroupStatistics(2, "date1"); err == nil {
+		t.Errorf("Test failed, expected an error for a missing stripe")
+	}
+}
+
+func TestParseDecimal(t *testing.T) {
+	for s, expected := range map[string]Decimal{
+		"123.45": NewDecimal(big.NewInt(12345), 2),
+		"-5.00":  NewDecimal(big.NewInt(-500), 2),
+		"7":      NewDecimal(big.NewInt(7), 0),
+		"1.5E-3": NewDecimal(big.NewInt(15), 4),
+	} {
+		d, err := parseDecimal(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.Scale != expected.Scale || d.Int.Cmp(expected.Int) != 0 {
+			t.Errorf("Test failed, expected %v got %v", expected, d)
+		}
+	}
+	if _, err := parseDecimal("abc"); err == nil {
+		t.Errorf("Test failed, expected an error parsing an invalid decimal")
+	}
+}
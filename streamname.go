@@ -1,13 +1,15 @@
 package orc
 
 import (
+	"bufio"
 	"fmt"
 	"io"
+	"sync"
 
 	"github.com/scritchley/orc/proto"
 )
 
-type streamMap map[streamName]io.Reader
+type streamMap map[streamName]*streamSection
 
 func (s streamMap) reset() {
 	for k := range s {
@@ -15,17 +17,45 @@ func (s streamMap) reset() {
 	}
 }
 
-func (s streamMap) set(name streamName, buf io.Reader) {
-	s[name] = buf
+func (s streamMap) set(name streamName, section *streamSection) {
+	s[name] = section
 }
 
+// get returns a new reader for the named stream, or nil if the stream does not exist.
 func (s streamMap) get(name streamName) io.Reader {
-	if b, ok := s[name]; ok {
-		return b
+	if section, ok := s[name]; ok {
+		return section.reader()
 	}
 	return nil
 }
 
+// streamSection records the location of a stream within an ORC file so that
+// it can be read lazily, one compressed chunk at a time.
+type streamSection struct {
+	r          io.ReaderAt
+	offset     int64
+	length     int64
+	codec      CompressionCodec
+	bufferSize int
+}
+
+// reader returns a new reader positioned at the start of the stream.
+func (s *streamSection) reader() io.Reader {
+	section := io.NewSectionReader(s.r, s.offset, s.length)
+	if _, ok := s.codec.(CompressionNone); ok {
+		return &streamReader{
+			Reader:  bufio.NewReaderSize(section, s.bufferSize),
+			section: section,
+		}
+	}
+	return &chunkedStreamReader{
+		section:     section,
+		r:           bufio.NewReaderSize(section, s.bufferSize),
+		codec:       s.codec,
+		chunkOffset: -1,
+	}
+}
+
 type streamName struct {
 	columnID int
 	kind     proto.Stream_Kind
@@ -81,6 +111,35 @@ func (w writerMap) forEach(fn func(i int, t TreeWriter) error) error {
 	return nil
 }
 
+// closeColumns closes each TreeWriter using up to concurrency goroutines. Each
+// TreeWriter is closed independently of its children, returning the error of
+// the TreeWriter with the lowest column ID if any fail.
+func (w writerMap) closeColumns(concurrency int) error {
+	ids := make(chan int)
+	errs := make([]error, len(w))
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range ids {
+				errs[id] = closeColumn(w[id])
+			}
+		}()
+	}
+	for id := 0; id < len(w); id++ {
+		ids <- id
+	}
+	close(ids)
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (w writerMap) size() int64 {
 	var size int
 	for _, treeWriter := range w {
@@ -0,0 +1,153 @@
+package orc
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// streamReader reads an uncompressed stream through a bounded read-ahead buffer
+// and implements seeker so that it can be moved to the positions recorded in
+// the row index.
+type streamReader struct {
+	*bufio.Reader
+	section *io.SectionReader
+}
+
+// seek moves the reader to the offset provided by p.
+func (s *streamReader) seek(p *positionProvider) error {
+	offset, err := p.next()
+	if err != nil {
+		return err
+	}
+	if offset > uint64(s.section.Size()) {
+		return fmt.Errorf("position %v exceeds stream length %v", offset, s.section.Size())
+	}
+	if _, err := s.section.Seek(int64(offset), io.SeekStart); err != nil {
+		return err
+	}
+	s.Reader.Reset(s.section)
+	return nil
+}
+
+// chunkedStreamReader reads a compressed stream through a bounded read-ahead
+// buffer, decompressing a single chunk at a time as it is consumed.
+type chunkedStreamReader struct {
+	section *io.SectionReader
+	r       *bufio.Reader
+	codec   CompressionCodec
+	// offset is the offset of the next chunk within the compressed stream.
+	offset int64
+	// chunkOffset is the offset of the current chunk within the compressed
+	// stream, or -1 if no chunk has been read since the reader was positioned.
+	chunkOffset int64
+	compressed  []byte
+	decoded     bytes.Buffer
+	chunk       []byte
+	pos         int
+}
+
+// readChunk reads and decompresses the next chunk of the stream, returning
+// io.EOF once all chunks have been read.
+func (s *chunkedStreamReader) readChunk() error {
+	if s.offset >= s.section.Size() {
+		return io.EOF
+	}
+	if cap(s.compressed) < 3 {
+		s.compressed = make([]byte, 3)
+	}
+	header := s.compressed[:3]
+	if _, err := io.ReadFull(s.r, header); err != nil {
+		return errCorruptChunkHeader
+	}
+	chunkLength := int(uint32(header[0])|uint32(header[1])<<8|uint32(header[2])<<16) >> 1
+	isOriginal := header[0]&1 == 1
+	if cap(s.compressed) < 3+chunkLength {
+		compressed := make([]byte, 3+chunkLength)
+		copy(compressed, header)
+		s.compressed = compressed
+	}
+	s.compressed = s.compressed[:3+chunkLength]
+	if _, err := io.ReadFull(s.r, s.compressed[3:]); err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	s.chunkOffset = s.offset
+	s.offset += int64(len(s.compressed))
+	s.pos = 0
+	if isOriginal {
+		s.chunk = s.compressed[3:]
+		return nil
+	}
+	s.decoded.Reset()
+	if _, err := s.decoded.ReadFrom(s.codec.Decoder(bytes.NewReader(s.compressed))); err != nil {
+		return err
+	}
+	s.chunk = s.decoded.Bytes()
+	return nil
+}
+
+// Read implements io.Reader.
+func (s *chunkedStreamReader) Read(p []byte) (int, error) {
+	for s.pos == len(s.chunk) {
+		if err := s.readChunk(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, s.chunk[s.pos:])
+	s.pos += n
+	return n, nil
+}
+
+// ReadByte implements io.ByteReader.
+func (s *chunkedStreamReader) ReadByte() (byte, error) {
+	for s.pos == len(s.chunk) {
+		if err := s.readChunk(); err != nil {
+			return 0, err
+		}
+	}
+	b := s.chunk[s.pos]
+	s.pos++
+	return b, nil
+}
+
+// seek moves the reader to the position provided by p, which consists of the
+// offset of a chunk within the compressed stream followed by an offset within
+// the decompressed chunk. The chunk is only read if it is not the current chunk.
+func (s *chunkedStreamReader) seek(p *positionProvider) error {
+	chunkOffset, err := p.next()
+	if err != nil {
+		return err
+	}
+	offset, err := p.next()
+	if err != nil {
+		return err
+	}
+	if chunkOffset > uint64(s.section.Size()) {
+		return fmt.Errorf("no compressed chunk at offset %v", chunkOffset)
+	}
+	if int64(chunkOffset) != s.chunkOffset {
+		if _, err := s.section.Seek(int64(chunkOffset), io.SeekStart); err != nil {
+			return err
+		}
+		s.r.Reset(s.section)
+		s.offset = int64(chunkOffset)
+		s.chunkOffset = -1
+		s.chunk = nil
+		s.pos = 0
+		// A position at the end of the stream follows the last chunk.
+		if s.offset < s.section.Size() {
+			if err := s.readChunk(); err != nil {
+				return err
+			}
+		}
+	}
+	if offset > uint64(len(s.chunk)) {
+		return fmt.Errorf("position %v exceeds compressed chunk length %v", offset, len(s.chunk))
+	}
+	s.pos = int(offset)
+	return nil
+}
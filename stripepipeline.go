@@ -0,0 +1,200 @@
+package orc
+
+import (
+	"sync"
+)
+
+// queuedBatches is the number of batches of decoded rows that each worker of a
+// stripePipeline queues ahead of the Cursor consuming its stripe.
+const queuedBatches = 4
+
+// preparedStripe holds a stripe decoded ahead of a Cursor. Its rows are decoded
+// by a worker of a stripePipeline into batches of DefaultBatchSize rows, which
+// are queued until the Cursor consumes them.
+type preparedStripe struct {
+	num    int
+	stripe *Stripe
+	// cursor reads the rows of the stripe on the worker decoding it.
+	cursor *Cursor
+	// batches receives the rows of the stripe as they are decoded, it is
+	// closed once the rows are exhausted or an error occurs.
+	batches chan *Batch
+	// free returns batches whose rows have been consumed to the worker so
+	// that their vectors are reused.
+	free chan *Batch
+	// stop is closed by the Cursor once it no longer consumes the stripe.
+	stop chan struct{}
+	// err is an error that occurred whilst preparing the stripe, in which
+	// case no rows are decoded.
+	err error
+	// decodeErr is an error that occurred whilst decoding the rows of the
+	// stripe, it is set before batches is closed.
+	decodeErr error
+}
+
+// decode reads the rows of the stripe into batches, queuing at most
+// queuedBatches of them, until the rows are exhausted, an error occurs or
+// decoding is stopped.
+func (s *preparedStripe) decode(done chan struct{}) {
+	defer close(s.batches)
+	for {
+		var b *Batch
+		select {
+		case b = <-s.free:
+		default:
+			b = NewBatch(DefaultBatchSize)
+		}
+		if !s.cursor.NextBatch(b) {
+			s.decodeErr = s.cursor.Err()
+			return
+		}
+		select {
+		case s.batches <- b:
+		case <-s.stop:
+			return
+		case <-done:
+			return
+		}
+	}
+}
+
+// release stops the decoding of the stripe once the Cursor no longer consumes
+// it.
+func (s *preparedStripe) release() {
+	close(s.stop)
+}
+
+// stripeJob is a stripe to be decoded by a worker of a stripePipeline, along
+// with the channel that its result is sent to.
+type stripeJob struct {
+	num    int
+	result chan *preparedStripe
+}
+
+// stripePipeline fetches and decodes the stripes of a file on a pool of
+// goroutines ahead of the Cursor consuming them.
+type stripePipeline struct {
+	done      chan struct{}
+	closeOnce sync.Once
+	// pending holds a result channel for each stripe in file order when the
+	// pipeline is ordered.
+	pending chan chan *preparedStripe
+	// results receives each stripe as soon as it is prepared when the pipeline
+	// is unordered.
+	results chan *preparedStripe
+}
+
+// newStripePipeline starts a pipeline that decodes the stripes from stripe
+// start onwards that might satisfy the predicate of the template Cursor, using
+// the provided number of workers. Each worker decodes a single stripe at a
+// time, so at most concurrency stripes are decoded ahead of the stripe being
+// consumed.
+func newStripePipeline(template *Cursor, start, numStripes, concurrency int, ordered bool) *stripePipeline {
+	p := &stripePipeline{
+		done: make(chan struct{}),
+	}
+	if ordered {
+		p.pending = make(chan chan *preparedStripe, concurrency)
+	} else {
+		p.results = make(chan *preparedStripe, concurrency)
+	}
+	jobs := make(chan stripeJob)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				prepared := template.prepareStripe(job.num)
+				select {
+				case job.result <- prepared:
+				case <-p.done:
+					return
+				}
+				if prepared.err == nil {
+					prepared.decode(p.done)
+				}
+			}
+		}()
+	}
+	go func() {
+		defer func() {
+			close(jobs)
+			if ordered {
+				close(p.pending)
+				return
+			}
+			wg.Wait()
+			close(p.results)
+		}()
+		for n := start; n < numStripes; n++ {
+			// Skip any stripes that cannot satisfy the predicate.
+			if !template.stripeMightMatch(n) {
+				continue
+			}
+			job := stripeJob{
+				num:    n,
+				result: p.results,
+			}
+			if ordered {
+				job.result = make(chan *preparedStripe, 1)
+				select {
+				case p.pending <- job.result:
+				case <-p.done:
+					return
+				}
+			}
+			select {
+			case jobs <- job:
+			case <-p.done:
+				return
+			}
+		}
+	}()
+	return p
+}
+
+// next returns the next prepared stripe, or false once all stripes have been
+// returned.
+func (p *stripePipeline) next() (*preparedStripe, bool) {
+	if p.results != nil {
+		prepared, ok := <-p.results
+		return prepared, ok
+	}
+	result, ok := <-p.pending
+	if !ok {
+		return nil, false
+	}
+	return <-result, true
+}
+
+// close stops the goroutines of the pipeline.
+func (p *stripePipeline) close() {
+	p.closeOnce.Do(func() {
+		close(p.done)
+	})
+}
+
+// prepareStripe reads the footer and row indexes of stripe n and creates the
+// readers of the columns selected by c, using a new Cursor that decodes the rows
+// of the stripe.
+func (c *Cursor) prepareStripe(n int) *preparedStripe {
+	d := &Cursor{
+		Reader:    c.Reader,
+		columns:   c.columns,
+		included:  c.included,
+		predicate: c.predicate,
+		evolution: c.evolution,
+	}
+	if err := d.SelectStripe(n); err != nil {
+		return &preparedStripe{num: n, err: err}
+	}
+	return &preparedStripe{
+		num:     n,
+		stripe:  d.Stripe,
+		cursor:  d,
+		batches: make(chan *Batch, queuedBatches),
+		free:    make(chan *Batch, queuedBatches),
+		stop:    make(chan struct{}),
+	}
+}
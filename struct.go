@@ -0,0 +1,317 @@
+package orc
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+)
+
+var (
+	valuerType  = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	dateType    = reflect.TypeOf(Date{})
+	decimalType = reflect.TypeOf(Decimal{})
+	ratType     = reflect.TypeOf(big.Rat{})
+	bigIntType  = reflect.TypeOf(big.Int{})
+	bytesType   = reflect.TypeOf([]byte(nil))
+
+	// nullTypes holds the categories of the types in database/sql that hold a
+	// value that may be null.
+	nullTypes = map[reflect.Type]Category{
+		reflect.TypeOf(sql.NullBool{}):    CategoryBoolean,
+		reflect.TypeOf(sql.NullInt32{}):   CategoryInt,
+		reflect.TypeOf(sql.NullInt64{}):   CategoryLong,
+		reflect.TypeOf(sql.NullFloat64{}): CategoryDouble,
+		reflect.TypeOf(sql.NullString{}):  CategoryString,
+		reflect.TypeOf(sql.NullTime{}):    CategoryTimestamp,
+	}
+)
+
+// SchemaFor returns a struct schema derived from the exported fields of the
+// struct, or pointer to a struct, v. Each field is named after its orc tag, for
+// example `orc:"int1"`, or otherwise the name of the field, and fields tagged
+// `orc:"-"` are skipped. The type of a field is derived from its Go type, which
+// can be overridden by following the name in the tag with the type as accepted
+// by ParseSchema, for example `orc:"price,decimal(10,2)"` or `orc:",char(3)"`.
+//
+// Go types map to the following types: bool to boolean, int8 to tinyint, int16
+// and uint8 to smallint, int32 and uint16 to int, int, int64 and uint32 to
+// bigint, float32 to float, float64 to double, string to string, []byte to
+// binary, time.Time to timestamp, Date to date, Decimal and big.Rat to
+// decimal, slices and arrays to array, maps to map and structs to struct.
+// Pointers and the Null types in database/sql map to the type they hold.
+func SchemaFor(v interface{}) (*TypeDescription, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a struct or pointer to a struct, got %T", v)
+	}
+	return schemaForType(t)
+}
+
+// schemaForType returns the schema of the Go type t.
+func schemaForType(t reflect.Type) (*TypeDescription, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if category, ok := nullTypes[t]; ok {
+		return NewTypeDescription(SetCategory(category))
+	}
+	switch t {
+	case timeType:
+		return NewTypeDescription(SetCategory(CategoryTimestamp))
+	case dateType:
+		return NewTypeDescription(SetCategory(CategoryDate))
+	case decimalType, ratType:
+		return NewTypeDescription(SetCategory(CategoryDecimal))
+	case bytesType:
+		return NewTypeDescription(SetCategory(CategoryBinary))
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return NewTypeDescription(SetCategory(CategoryBoolean))
+	case reflect.Int8:
+		return NewTypeDescription(SetCategory(CategoryByte))
+	case reflect.Int16, reflect.Uint8:
+		return NewTypeDescription(SetCategory(CategoryShort))
+	case reflect.Int32, reflect.Uint16:
+		return NewTypeDescription(SetCategory(CategoryInt))
+	case reflect.Int, reflect.Int64, reflect.Uint32:
+		return NewTypeDescription(SetCategory(CategoryLong))
+	case reflect.Float32:
+		return NewTypeDescription(SetCategory(CategoryFloat))
+	case reflect.Float64:
+		return NewTypeDescription(SetCategory(CategoryDouble))
+	case reflect.String:
+		return NewTypeDescription(SetCategory(CategoryString))
+	case reflect.Slice, reflect.Array:
+		child, err := schemaForType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return createList(child)
+	case reflect.Map:
+		key, err := schemaForType(t.Key())
+		if err != nil {
+			return nil, err
+		}
+		value, err := schemaForType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return createMap(key, value)
+	case reflect.Struct:
+		td, err := NewTypeDescription(SetCategory(CategoryStruct))
+		if err != nil {
+			return nil, err
+		}
+		for _, field := range cachedStructFields(t) {
+			var ft *TypeDescription
+			if field.typ != "" {
+				ft, err = ParseSchema(field.typ)
+			} else {
+				ft, err = schemaForType(field.t)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %v", field.name, err)
+			}
+			if err := td.addField(field.columnName(), ft); err != nil {
+				return nil, err
+			}
+		}
+		return td, nil
+	}
+	return nil, fmt.Errorf("cannot derive a column type for %s", t)
+}
+
+// structValue converts the Go value v to the value written by the TreeWriter
+// of td. Structs are converted to a []interface{} holding the values of the
+// fields matching the names of the fields of td, in the order of td.
+func structValue(td *TypeDescription, v reflect.Value) (interface{}, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil, nil
+	}
+	if v.Type().Implements(valuerType) {
+		value, err := v.Interface().(driver.Valuer).Value()
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			return nil, nil
+		}
+		v = reflect.ValueOf(value)
+	}
+	switch td.category {
+	case CategoryBoolean:
+		if v.Kind() == reflect.Bool {
+			return v.Bool(), nil
+		}
+	case CategoryByte:
+		i, err := structInt(v, 8)
+		return int8(i), err
+	case CategoryShort:
+		return structInt(v, 16)
+	case CategoryInt:
+		return structInt(v, 32)
+	case CategoryLong:
+		return structInt(v, 64)
+	case CategoryFloat:
+		switch v.Kind() {
+		case reflect.Float32, reflect.Float64:
+			return float32(v.Float()), nil
+		}
+	case CategoryDouble:
+		switch v.Kind() {
+		case reflect.Float32, reflect.Float64:
+			return v.Float(), nil
+		}
+	case CategoryString, CategoryVarchar, CategoryChar:
+		switch {
+		case v.Kind() == reflect.String:
+			return v.String(), nil
+		case v.Type() == bytesType:
+			return string(v.Bytes()), nil
+		}
+	case CategoryBinary:
+		switch {
+		case v.Type() == bytesType:
+			if v.IsNil() {
+				return nil, nil
+			}
+			return v.Bytes(), nil
+		case v.Kind() == reflect.String:
+			return []byte(v.String()), nil
+		}
+	case CategoryTimestamp, CategoryTimestampInstant, CategoryDate:
+		switch v.Type() {
+		case timeType:
+			return v.Interface(), nil
+		case dateType:
+			return v.Interface().(Date).Time, nil
+		}
+	case CategoryDecimal:
+		return structDecimal(v)
+	case CategoryList:
+		switch v.Kind() {
+		case reflect.Slice, reflect.Array:
+			if v.Kind() == reflect.Slice && v.IsNil() {
+				return nil, nil
+			}
+			values := make([]interface{}, v.Len())
+			for i := range values {
+				value, err := structValue(td.children[0], v.Index(i))
+				if err != nil {
+					return nil, err
+				}
+				values[i] = value
+			}
+			return values, nil
+		}
+	case CategoryMap:
+		if v.Kind() == reflect.Map {
+			if v.IsNil() {
+				return nil, nil
+			}
+			values := make(map[interface{}]interface{}, v.Len())
+			for _, k := range v.MapKeys() {
+				key, err := structValue(td.children[0], k)
+				if err != nil {
+					return nil, err
+				}
+				if key == nil || !reflect.TypeOf(key).Comparable() {
+					return nil, fmt.Errorf("cannot write map key %v to %s column type", k, td.children[0].category)
+				}
+				value, err := structValue(td.children[1], v.MapIndex(k))
+				if err != nil {
+					return nil, err
+				}
+				values[key] = value
+			}
+			return values, nil
+		}
+	case CategoryStruct:
+		if v.Kind() == reflect.Struct {
+			fields := cachedStructFields(v.Type())
+			values := make([]interface{}, len(td.children))
+			for i, name := range td.fieldNames {
+				field, ok := fields.lookup(name)
+				if !ok {
+					return nil, fmt.Errorf("no field of %s matches column %s", v.Type(), name)
+				}
+				value, err := structValue(td.children[i], v.Field(field.index))
+				if err != nil {
+					return nil, fmt.Errorf("field %s: %v", field.name, err)
+				}
+				values[i] = value
+			}
+			return values, nil
+		}
+	case CategoryUnion:
+		if value, ok := v.Interface().(UnionValue); ok {
+			return value, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot write %s to %s column type", v.Type(), td.category)
+}
+
+// structInt returns the integer value of v, returning an error if it does not
+// fit in a signed integer of the given number of bits.
+func structInt(v reflect.Value, bits uint) (int64, error) {
+	var i int64
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i = v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.Uint() > math.MaxInt64 {
+			return 0, fmt.Errorf("value %v overflows %v bit integer", v.Uint(), bits)
+		}
+		i = int64(v.Uint())
+	default:
+		return 0, fmt.Errorf("cannot write %s to integer column type", v.Type())
+	}
+	if bits < 64 {
+		if min, max := int64(-1)<<(bits-1), int64(1)<<(bits-1)-1; i < min || i > max {
+			return 0, fmt.Errorf("value %v overflows %v bit integer", i, bits)
+		}
+	}
+	return i, nil
+}
+
+// structDecimal returns the decimal value of v as a value accepted by the
+// DecimalTreeWriter.
+func structDecimal(v reflect.Value) (interface{}, error) {
+	switch v.Type() {
+	case decimalType, ratType:
+		return v.Interface(), nil
+	case bigIntType:
+		i := v.Interface().(big.Int)
+		return &i, nil
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(v.Uint())), nil
+	case reflect.Float32, reflect.Float64:
+		if r := new(big.Rat).SetFloat64(v.Float()); r != nil {
+			return r, nil
+		}
+		return nil, fmt.Errorf("cannot write %v to decimal column type", v.Float())
+	case reflect.String:
+		if r, ok := new(big.Rat).SetString(v.String()); ok {
+			return r, nil
+		}
+		return nil, fmt.Errorf("cannot parse %q as a decimal", v.String())
+	}
+	return nil, fmt.Errorf("cannot write %s to decimal column type", v.Type())
+}
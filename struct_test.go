@@ -0,0 +1,190 @@
+package orc
+
+import (
+	"bytes"
+	"database/sql"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type structPoint struct {
+	X int32 `orc:"x"`
+	Y float64
+}
+
+type structRow struct {
+	ID       int64            `orc:"id"`
+	Byte     int8             `orc:"byte1"`
+	Name     *string          `orc:"name"`
+	Code     string           `orc:"code,char(3)"`
+	Price    Decimal          `orc:"price,decimal(10,2)"`
+	Day      Date             `orc:"day"`
+	Count    sql.NullInt64    `orc:"count"`
+	Point    *structPoint     `orc:"point"`
+	Tags     map[string]int64 `orc:"tags"`
+	Nums     []int32          `orc:"nums"`
+	Blob     []byte           `orc:"blob"`
+	Ignored  string           `orc:"-"`
+	internal int
+}
+
+func TestSchemaFor(t *testing.T) {
+	schema, err := SchemaFor(&structRow{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "struct<id:bigint,byte1:tinyint,name:string,code:char(3),price:decimal(10,2),day:date,count:bigint,point:struct<x:int,Y:double>,tags:map<string,bigint>,nums:array<int>,blob:binary>"
+	if s := schema.String(); s != expected {
+		t.Errorf("Test failed, expected %s got %s", expected, s)
+	}
+
+	if _, err := SchemaFor(1); err == nil {
+		t.Errorf("Test failed, expected an error deriving a schema for an int")
+	}
+	var unsupported struct {
+		Value interface{}
+	}
+	if _, err := SchemaFor(unsupported); err == nil {
+		t.Errorf("Test failed, expected an error deriving a schema for an interface field")
+	}
+	var invalid struct {
+		Value string `orc:"value,decimal(1"`
+	}
+	if _, err := SchemaFor(invalid); err == nil {
+		t.Errorf("Test failed, expected an error deriving a schema from an invalid tag")
+	}
+}
+
+func TestWriterWriteStruct(t *testing.T) {
+	schema, err := SchemaFor(structRow{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, SetSchema(schema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := "one"
+	rows := []structRow{
+		{
+			ID:    1,
+			Byte:  -3,
+			Name:  &name,
+			Code:  "abc",
+			Price: NewDecimal(big.NewInt(1234), 2),
+			Day:   Date{time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC)},
+			Count: sql.NullInt64{Int64: 10, Valid: true},
+			Point: &structPoint{X: 1, Y: 0.5},
+			Tags:  map[string]int64{"a": 1},
+			Nums:  []int32{1, 2, 3},
+			Blob:  []byte("blob"),
+		},
+		{
+			ID:    2,
+			Code:  "def",
+			Price: NewDecimal(big.NewInt(-5), 2),
+			Day:   Date{time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+	for i := range rows {
+		if err := w.WriteStruct(&rows[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Fields are written to the columns matching their names regardless of
+	// the order they are declared in.
+	reordered := struct {
+		Nums  []int32
+		Point struct {
+			Y float64
+			X int32 `orc:"x"`
+		}
+		Price string `orc:"price"`
+		Day   time.Time
+		ID    int
+		Code  string
+		Byte  int `orc:"byte1"`
+		Name  string
+		Count *int64
+		Tags  map[string]int
+		Blob  string
+		Extra bool
+	}{
+		Nums:  []int32{4},
+		Price: "0.10",
+		Day:   time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC),
+		ID:    3,
+		Code:  "ghi",
+		Byte:  127,
+		Name:  "three",
+		Tags:  map[string]int{"b": 2},
+		Blob:  "data",
+	}
+	reordered.Point.X = 7
+	if err := w.WriteStruct(reordered); err != nil {
+		t.Fatal(err)
+	}
+	three := "three"
+	rows = append(rows, structRow{
+		ID:    3,
+		Byte:  127,
+		Name:  &three,
+		Code:  "ghi",
+		Price: NewDecimal(big.NewInt(10), 2),
+		Day:   Date{reordered.Day},
+		Point: &structPoint{X: 7},
+		Tags:  map[string]int64{"b": 2},
+		Nums:  []int32{4},
+		Blob:  []byte("data"),
+	})
+
+	// Rows that cannot be written return an error without writing a row.
+	var missing struct {
+		ID int64 `orc:"id"`
+	}
+	if err := w.WriteStruct(missing); err == nil {
+		t.Errorf("Test failed, expected an error writing a struct without a field for each column")
+	}
+	wrongType := struct {
+		structRow
+		Byte int `orc:"byte1"`
+	}{structRow: rows[1], Byte: 128}
+	if err := w.WriteStruct(wrongType); err == nil {
+		t.Errorf("Test failed, expected an error writing a value that overflows a tinyint")
+	}
+	if err := w.WriteStruct(nil); err == nil {
+		t.Errorf("Test failed, expected an error writing nil")
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(&bytesSizedReaderAt{bytes.NewBuffer(buf.Bytes())})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := r.NumRows(); n != len(rows) {
+		t.Fatalf("Test failed, expected %v rows got %v", len(rows), n)
+	}
+	c := r.Select(schema.Columns()...)
+	var got []structRow
+	for c.Stripes() {
+		for c.Next() {
+			var row structRow
+			if err := c.ScanStruct(&row); err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, row)
+		}
+	}
+	if err := c.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Errorf("Test failed, expected %+v got %+v", rows, got)
+	}
+}
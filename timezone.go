@@ -0,0 +1,44 @@
+package orc
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// timezones caches the locations loaded by loadTimezone by name.
+var timezones sync.Map
+
+// loadTimezone returns the location of the timezone recorded in a stripe
+// footer, which is the name of a timezone in the tz database. Files without a
+// timezone, and those written in UTC or GMT, return time.UTC.
+func loadTimezone(name string) (*time.Location, error) {
+	switch name {
+	case "", "UTC", "GMT":
+		return time.UTC, nil
+	case "Local":
+		// time.LoadLocation returns the local timezone of the reader for
+		// "Local", which is not necessarily the timezone of the writer.
+		return nil, fmt.Errorf("unknown timezone: %s", name)
+	}
+	if loc, ok := timezones.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone: %s", name)
+	}
+	timezones.Store(name, loc)
+	return loc, nil
+}
+
+// timestampBase returns the seconds since the epoch of 1 January 2015 in loc,
+// which the seconds of timestamps written in loc are relative to. As the base
+// uses the offset of loc in January, timestamps written during daylight saving
+// time are not offset by an additional hour, matching the Java implementation.
+func timestampBase(loc *time.Location) int64 {
+	if loc == nil || loc == time.UTC {
+		return TimestampBaseSeconds
+	}
+	return time.Date(2015, time.January, 1, 0, 0, 0, 0, loc).Unix()
+}
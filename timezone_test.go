@@ -0,0 +1,218 @@
+package orc
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestReaderTimestampLocation(t *testing.T) {
+	pacific, err := time.LoadLocation("US/Pacific")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	// The file was written in US/Pacific, including timestamps in daylight
+	// saving time, which are relative to 1 January 2015 in US/Pacific.
+	r, err := Open("./examples/TestOrcFile.testTimestamp.orc", SetTimestampLocation(pacific))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	expected := []string{
+		"2037-01-01 00:00:00.000999",
+		"2003-01-01 00:00:00.000000222",
+		"1999-01-01 00:00:00.999999999",
+		"1995-01-01 00:00:00.688888888",
+		"2002-01-01 00:00:00.1",
+		"2010-03-02 00:00:00.000009001",
+		"2005-01-01 00:00:00.000002229",
+		"2006-01-01 00:00:00.900203003",
+		"2003-01-01 00:00:00.800000007",
+		"1996-08-02 00:00:00.723100809",
+		"1998-11-02 00:00:00.857340643",
+		"2008-10-02 00:00:00",
+	}
+	c := r.Select("*")
+	var i int
+	for c.Stripes() {
+		for c.Next() {
+			if i >= len(expected) {
+				t.Fatalf("Test failed, expected %v rows", len(expected))
+			}
+			ts := c.Row()[0].(time.Time)
+			if ts.Location() != pacific {
+				t.Errorf("Test failed, expected location %v got %v", pacific, ts.Location())
+			}
+			if got := ts.Format("2006-01-02 15:04:05.999999999"); got != expected[i] {
+				t.Errorf("Test failed, expected %s got %s", expected[i], got)
+			}
+			i++
+		}
+	}
+	if err := c.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if i != len(expected) {
+		t.Errorf("Test failed, expected %v rows got %v", len(expected), i)
+	}
+
+	if _, err := NewReader(nil, SetTimestampLocation(nil)); err == nil {
+		t.Errorf("Test failed, expected an error for a nil location")
+	}
+}
+
+func TestWriterTimezone(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	schema, err := ParseSchema("struct<timestamp1:timestamp>")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	w, err := NewWriter(buf, SetSchema(schema), SetWriterTimezone(la))
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := []time.Time{
+		time.Date(2020, 1, 1, 12, 30, 0, 500, la),
+		time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC),
+	}
+	for _, v := range values {
+		if err := w.Write(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(&bytesSizedReaderAt{bytes.NewBuffer(buf.Bytes())})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := r.Select("timestamp1")
+	c.Stripes()
+	if got := c.Stripe.writerTimezone; got.String() != "America/Los_Angeles" {
+		t.Errorf("Test failed, expected writer timezone %v got %v", la, got)
+	}
+
+	// Timestamps are returned as the same instant in UTC by default.
+	for _, v := range values {
+		if !c.Next() {
+			t.Fatalf("Test failed, expected a row for %v", v)
+		}
+		ts := c.Row()[0].(time.Time)
+		if !ts.Equal(v) || ts.Location() != time.UTC {
+			t.Errorf("Test failed, expected %v got %v", v.UTC(), ts)
+		}
+	}
+
+	// The seconds are written relative to 1 January 2015 in the writer
+	// timezone, including those of timestamps in daylight saving time.
+	base := time.Date(2015, time.January, 1, 0, 0, 0, 0, la).Unix()
+	c = r.Select("timestamp1")
+	c.Stripes()
+	c.readers[0].(*TimestampTreeReader).base = TimestampBaseSeconds
+	for _, v := range values {
+		if !c.Next() {
+			t.Fatalf("Test failed, expected a row for %v", v)
+		}
+		got := c.Row()[0].(time.Time).Unix() - TimestampBaseSeconds
+		if expected := v.Unix() - base; got != expected {
+			t.Errorf("Test failed, expected %v to be written as %v got %v", v, expected, got)
+		}
+	}
+
+	// The statistics record the wall clock time in the writer timezone as well
+	// as UTC, as other ORC readers expect.
+	tsStats := r.footer.GetStatistics()[1].GetTimestampStatistics()
+	if got, expected := tsStats.GetMinimum()-tsStats.GetMinimumUtc(), int64(-8*60*60*1000); got != expected {
+		t.Errorf("Test failed, expected the minimum to be offset by %v got %v", expected, got)
+	}
+	stats, err := r.ColumnStatistics("timestamp1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := stats.(*TimestampStats)
+	if !ts.Min.Equal(values[2]) || !ts.Max.Equal(values[1]) {
+		t.Errorf("Test failed, expected statistics from %v to %v got %+v", values[2], values[1], ts)
+	}
+
+	for _, loc := range []*time.Location{nil, time.Local, time.FixedZone("XYZ", 3600)} {
+		if _, err := NewWriter(&bytes.Buffer{}, SetSchema(schema), SetWriterTimezone(loc)); err == nil {
+			t.Errorf("Test failed, expected an error for writer timezone %v", loc)
+		}
+	}
+}
+
+func TestTimestampInstant(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	schema, err := ParseSchema("struct<timestamp1:timestamp,instant1:timestamp with local time zone>")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	w, err := NewWriter(buf, SetSchema(schema), SetWriterTimezone(la))
+	if err != nil {
+		t.Fatal(err)
+	}
+	value := time.Date(2020, 1, 1, 12, 30, 0, 0, la)
+	if err := w.Write(value, value); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(&bytesSizedReaderAt{bytes.NewBuffer(buf.Bytes())}, SetTimestampLocation(la))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := r.Schema().String(); got != schema.String() {
+		t.Errorf("Test failed, expected schema %s got %s", schema, got)
+	}
+
+	// Timestamps with local time zone are written in UTC regardless of the
+	// writer timezone, so the statistics have no offset.
+	tsStats := r.footer.GetStatistics()[2].GetTimestampStatistics()
+	if tsStats.GetMinimum() != tsStats.GetMinimumUtc() {
+		t.Errorf("Test failed, expected the minimum %v to be in UTC got %v", tsStats.GetMinimumUtc(), tsStats.GetMinimum())
+	}
+
+	c := r.Select("timestamp1", "instant1")
+	var rows [][]interface{}
+	for c.Stripes() {
+		for c.Next() {
+			rows = append(rows, c.Row())
+		}
+	}
+	if err := c.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("Test failed, expected 2 rows got %v", len(rows))
+	}
+	for i, v := range rows[0] {
+		if ts, ok := v.(time.Time); !ok || !ts.Equal(value) || ts.Location() != la {
+			t.Errorf("Test failed, expected %v in column %v got %v", value, i, v)
+		}
+	}
+	if rows[1][0] != nil || rows[1][1] != nil {
+		t.Errorf("Test failed, expected nulls got %v", rows[1])
+	}
+}
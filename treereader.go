@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"strings"
 	"time"
 
 	"github.com/scritchley/orc/proto"
@@ -24,6 +25,16 @@ type TreeReader interface {
 	Err() error
 }
 
+// byteReader returns r as an io.ByteReader, wrapping it in a bufio.Reader if it
+// does not implement io.ByteReader. Streams are read directly so that they can
+// seek to the positions recorded in the row index.
+func byteReader(r io.Reader) io.ByteReader {
+	if br, ok := r.(io.ByteReader); ok {
+		return br
+	}
+	return bufio.NewReader(r)
+}
+
 // BaseTreeReader wraps a *BooleanReader and is used for reading the Present stream
 // in all TreeReader implementations.
 type BaseTreeReader struct {
@@ -35,7 +46,7 @@ func NewBaseTreeReader(r io.Reader) BaseTreeReader {
 	if r == nil {
 		return BaseTreeReader{}
 	}
-	return BaseTreeReader{NewBooleanReader(bufio.NewReader(r))}
+	return BaseTreeReader{NewBooleanReader(byteReader(r))}
 }
 
 // Next returns the next available value.
@@ -54,6 +65,14 @@ func (b BaseTreeReader) IsPresent() bool {
 	return true
 }
 
+// seek seeks the present stream, if the column has one, to the positions provided by p.
+func (b BaseTreeReader) seek(p *positionProvider) error {
+	if b.BooleanReader != nil {
+		return b.BooleanReader.seek(p)
+	}
+	return nil
+}
+
 // Err returns the last error to occur.
 func (b BaseTreeReader) Err() error {
 	if b.BooleanReader != nil {
@@ -102,6 +121,13 @@ func (i *IntegerTreeReader) Err() error {
 	return i.BaseTreeReader.Err()
 }
 
+func (i *IntegerTreeReader) seek(p *positionProvider) error {
+	if err := i.BaseTreeReader.seek(p); err != nil {
+		return err
+	}
+	return seekReader(i.IntegerReader, p)
+}
+
 // NewIntegerTreeReader returns a new IntegerReader or an error if one occurs.
 func NewIntegerTreeReader(present, data io.Reader, encoding *proto.ColumnEncoding) (*IntegerTreeReader, error) {
 	ireader, err := createIntegerReader(encoding.GetKind(), data, true, false)
@@ -117,9 +143,9 @@ func NewIntegerTreeReader(present, data io.Reader, encoding *proto.ColumnEncodin
 func createIntegerReader(kind proto.ColumnEncoding_Kind, in io.Reader, signed, skipCorrupt bool) (IntegerReader, error) {
 	switch kind {
 	case proto.ColumnEncoding_DIRECT_V2, proto.ColumnEncoding_DICTIONARY_V2:
-		return NewRunLengthIntegerReaderV2(bufio.NewReader(in), signed, skipCorrupt), nil
+		return NewRunLengthIntegerReaderV2(byteReader(in), signed, skipCorrupt), nil
 	case proto.ColumnEncoding_DIRECT, proto.ColumnEncoding_DICTIONARY:
-		return NewRunLengthIntegerReader(bufio.NewReader(in), signed), nil
+		return NewRunLengthIntegerReader(byteReader(in), signed), nil
 	default:
 		return nil, fmt.Errorf("unknown encoding: %s", kind)
 	}
@@ -135,6 +161,11 @@ type TimestampTreeReader struct {
 	BaseTreeReader
 	data      IntegerReader
 	secondary IntegerReader
+	// base is the seconds since the epoch of 1 January 2015 in the writer
+	// timezone, and location is the location timestamps are returned in, UTC
+	// if nil.
+	base     int64
+	location *time.Location
 }
 
 // Next implements the TreeReader interface.
@@ -148,7 +179,8 @@ func (t *TimestampTreeReader) Next() bool {
 	return t.data.Next() && t.secondary.Next()
 }
 
-// ValueTimestamp returns the next timestamp value.
+// Timestamp returns the next timestamp value. Timestamps are written relative
+// to 1 January 2015 in the timezone of the writer, recorded in the stripe footer.
 func (t *TimestampTreeReader) Timestamp() time.Time {
 	nanos := t.secondary.Int()
 	zeros := nanos & 0x7
@@ -158,7 +190,11 @@ func (t *TimestampTreeReader) Timestamp() time.Time {
 			nanos = nanos * 10
 		}
 	}
-	return time.Unix(TimestampBaseSeconds+t.data.Int(), nanos).UTC()
+	ts := time.Unix(t.base+t.data.Int(), nanos)
+	if t.location != nil {
+		return ts.In(t.location)
+	}
+	return ts.UTC()
 }
 
 // Value implements the TreeReader interface.
@@ -177,6 +213,16 @@ func (t *TimestampTreeReader) Err() error {
 	return t.secondary.Err()
 }
 
+func (t *TimestampTreeReader) seek(p *positionProvider) error {
+	if err := t.BaseTreeReader.seek(p); err != nil {
+		return err
+	}
+	if err := seekReader(t.data, p); err != nil {
+		return err
+	}
+	return seekReader(t.secondary, p)
+}
+
 // NewTimestampTreeReader returns a new TimestampTreeReader along with any error that occurs.
 func NewTimestampTreeReader(present, data, secondary io.Reader, encoding *proto.ColumnEncoding) (*TimestampTreeReader, error) {
 	dataReader, err := createIntegerReader(encoding.GetKind(), data, true, false)
@@ -191,6 +237,7 @@ func NewTimestampTreeReader(present, data, secondary io.Reader, encoding *proto.
 		BaseTreeReader: NewBaseTreeReader(present),
 		data:           dataReader,
 		secondary:      secondaryReader,
+		base:           TimestampBaseSeconds,
 	}, nil
 }
 
@@ -211,6 +258,9 @@ func (d *DateTreeReader) Date() Date {
 
 // Value implements the TreeReader interface.
 func (d *DateTreeReader) Value() interface{} {
+	if !d.IsPresent() {
+		return nil
+	}
 	return d.Date()
 }
 
@@ -240,6 +290,35 @@ func NewStringTreeReader(present, data, length, dictionary io.Reader, encoding *
 	return nil, fmt.Errorf("unsupported column encoding: %s", encoding.GetKind())
 }
 
+// CharTreeReader is a StringTreeReader implementation that removes the trailing
+// space padding from char type column values.
+type CharTreeReader struct {
+	StringTreeReader
+}
+
+// NewCharTreeReader returns a new CharTreeReader wrapping the provided StringTreeReader.
+func NewCharTreeReader(r StringTreeReader) *CharTreeReader {
+	return &CharTreeReader{r}
+}
+
+// String returns the next value with any trailing spaces removed.
+func (c *CharTreeReader) String() string {
+	return strings.TrimRight(c.StringTreeReader.String(), " ")
+}
+
+func (c *CharTreeReader) seek(p *positionProvider) error {
+	return seekReader(c.StringTreeReader, p)
+}
+
+// Value implements the TreeReader interface.
+func (c *CharTreeReader) Value() interface{} {
+	v := c.StringTreeReader.Value()
+	if str, ok := v.(string); ok {
+		return strings.TrimRight(str, " ")
+	}
+	return v
+}
+
 // StringDirectTreeReader is a StringTreeReader implementation that can read direct
 // encoded string type columns.
 type StringDirectTreeReader struct {
@@ -280,7 +359,7 @@ func (s *StringDirectTreeReader) String() string {
 	if l == 0 {
 		return ""
 	}
-	n, err := s.data.Read(byt)
+	n, err := io.ReadFull(s.data, byt)
 	if err != nil {
 		s.err = err
 		return ""
@@ -299,6 +378,16 @@ func (s *StringDirectTreeReader) Value() interface{} {
 	return s.String()
 }
 
+func (s *StringDirectTreeReader) seek(p *positionProvider) error {
+	if err := s.BaseTreeReader.seek(p); err != nil {
+		return err
+	}
+	if err := seekReader(s.data, p); err != nil {
+		return err
+	}
+	return seekReader(s.length, p)
+}
+
 func (s *StringDirectTreeReader) Err() error {
 	if s.err != nil {
 		return s.err
@@ -396,20 +485,21 @@ func (s *StringDictionaryTreeReader) getIndexLength(i int) (int, int) {
 }
 
 func (s *StringDictionaryTreeReader) String() string {
+	return string(s.bytes())
+}
+
+// bytes returns the next value as a slice of the dictionary.
+func (s *StringDictionaryTreeReader) bytes() []byte {
 	if len(s.dictionaryBytes) == 0 {
-		return ""
-	}
-	v := s.reader.Value()
-	if v == nil {
-		return ""
+		return nil
 	}
-	i := v.(int64)
+	i := s.reader.Int()
 	offset, length := s.getIndexLength(int(i))
 	if offset > len(s.dictionaryBytes) || offset+length > len(s.dictionaryBytes) {
 		s.err = fmt.Errorf("invalid offset:%v or length:%v, greater than dictionary size:%v", offset, length, len(s.dictionaryBytes))
-		return ""
+		return nil
 	}
-	return string(s.dictionaryBytes[offset : offset+length])
+	return s.dictionaryBytes[offset : offset+length]
 }
 
 func (s *StringDictionaryTreeReader) Value() interface{} {
@@ -419,6 +509,13 @@ func (s *StringDictionaryTreeReader) Value() interface{} {
 	return s.String()
 }
 
+func (s *StringDictionaryTreeReader) seek(p *positionProvider) error {
+	if err := s.BaseTreeReader.seek(p); err != nil {
+		return err
+	}
+	return seekReader(s.reader, p)
+}
+
 func (s *StringDictionaryTreeReader) Err() error {
 	if s.err != nil {
 		return s.err
@@ -451,6 +548,13 @@ func (b *BooleanTreeReader) Value() interface{} {
 	return b.Bool()
 }
 
+func (b *BooleanTreeReader) seek(p *positionProvider) error {
+	if err := b.BaseTreeReader.seek(p); err != nil {
+		return err
+	}
+	return b.BooleanReader.seek(p)
+}
+
 func (b *BooleanTreeReader) Err() error {
 	if err := b.BooleanReader.Err(); err != nil {
 		return err
@@ -461,7 +565,7 @@ func (b *BooleanTreeReader) Err() error {
 func NewBooleanTreeReader(present, data io.Reader, encoding *proto.ColumnEncoding) (*BooleanTreeReader, error) {
 	return &BooleanTreeReader{
 		NewBaseTreeReader(present),
-		NewBooleanReader(bufio.NewReader(data)),
+		NewBooleanReader(byteReader(data)),
 	}, nil
 }
 
@@ -487,6 +591,13 @@ func (b *ByteTreeReader) Value() interface{} {
 	return b.RunLengthByteReader.Value()
 }
 
+func (b *ByteTreeReader) seek(p *positionProvider) error {
+	if err := b.BaseTreeReader.seek(p); err != nil {
+		return err
+	}
+	return b.RunLengthByteReader.seek(p)
+}
+
 func (b *ByteTreeReader) Err() error {
 	if err := b.RunLengthByteReader.Err(); err != nil {
 		return err
@@ -497,7 +608,7 @@ func (b *ByteTreeReader) Err() error {
 func NewByteTreeReader(present, data io.Reader, encoding *proto.ColumnEncoding) (*ByteTreeReader, error) {
 	return &ByteTreeReader{
 		NewBaseTreeReader(present),
-		NewRunLengthByteReader(bufio.NewReader(data)),
+		NewRunLengthByteReader(byteReader(data)),
 	}, nil
 }
 
@@ -507,17 +618,18 @@ type MapTreeReader struct {
 	length IntegerReader
 	key    TreeReader
 	value  TreeReader
+	err    error
 }
 
 // Next returns true if another row is available.
 func (m *MapTreeReader) Next() bool {
-	if !m.BaseTreeReader.Next() {
+	if m.err != nil || !m.BaseTreeReader.Next() {
 		return false
 	}
 	if !m.BaseTreeReader.IsPresent() {
 		return true
 	}
-	return m.length.Next() && m.key.Next() && m.value.Next()
+	return m.length.Next()
 }
 
 // MapEntry is an individual entry in a Map.
@@ -531,15 +643,18 @@ func (m *MapTreeReader) Map() []MapEntry {
 	l := int(m.length.Int())
 	kv := make([]MapEntry, l)
 	for i := 0; i < l; i++ {
+		if !m.key.Next() {
+			m.err = nextErr(m.key)
+			return kv[:i]
+		}
+		if !m.value.Next() {
+			m.err = nextErr(m.value)
+			return kv[:i]
+		}
 		kv[i] = MapEntry{
 			Key:   m.key.Value(),
 			Value: m.value.Value(),
 		}
-		if i-1 == l {
-			break
-		}
-		m.key.Next()
-		m.value.Next()
 	}
 	return kv
 }
@@ -552,6 +667,27 @@ func (m *MapTreeReader) Value() interface{} {
 	return m.Map()
 }
 
+// seek seeks the map column to the positions provided by p. The key and value
+// columns are seeked separately using their own positions.
+func (m *MapTreeReader) seek(p *positionProvider) error {
+	if err := m.BaseTreeReader.seek(p); err != nil {
+		return err
+	}
+	return seekReader(m.length, p)
+}
+
+// Err returns the first error to have occurred reading the map or its keys
+// and values.
+func (m *MapTreeReader) Err() error {
+	if m.err != nil {
+		return m.err
+	}
+	if err := m.length.Err(); err != nil {
+		return err
+	}
+	return m.BaseTreeReader.Err()
+}
+
 // NewMapTreeReader returns a new instance of a MapTreeReader.
 func NewMapTreeReader(present, length io.Reader, key, value TreeReader, encoding *proto.ColumnEncoding) (*MapTreeReader, error) {
 	lengthReader, err := createIntegerReader(encoding.GetKind(), length, false, false)
@@ -559,10 +695,10 @@ func NewMapTreeReader(present, length io.Reader, key, value TreeReader, encoding
 		return nil, err
 	}
 	return &MapTreeReader{
-		NewBaseTreeReader(present),
-		lengthReader,
-		key,
-		value,
+		BaseTreeReader: NewBaseTreeReader(present),
+		length:         lengthReader,
+		key:            key,
+		value:          value,
 	}, nil
 }
 
@@ -608,6 +744,15 @@ func (r *ListTreeReader) Value() interface{} {
 	return r.List()
 }
 
+// seek seeks the list column to the positions provided by p. The value column is
+// seeked separately using its own positions.
+func (r *ListTreeReader) seek(p *positionProvider) error {
+	if err := r.BaseTreeReader.seek(p); err != nil {
+		return err
+	}
+	return seekReader(r.length, p)
+}
+
 func (r *ListTreeReader) Err() error {
 	if r.err != nil {
 		return r.err
@@ -699,7 +844,7 @@ func (r *FloatTreeReader) Next() bool {
 
 func (r *FloatTreeReader) Float() Float {
 	bs := make([]byte, r.bytesPerValue, r.bytesPerValue)
-	n, err := r.Reader.Read(bs)
+	n, err := io.ReadFull(r.Reader, bs)
 	if err != nil {
 		r.err = err
 		return 0
@@ -717,7 +862,7 @@ type Double float64
 // Double returns the next Double value.
 func (r *FloatTreeReader) Double() Double {
 	bs := make([]byte, r.bytesPerValue, r.bytesPerValue)
-	n, err := r.Reader.Read(bs)
+	n, err := io.ReadFull(r.Reader, bs)
 	if err != nil {
 		r.err = err
 		return 0
@@ -739,6 +884,13 @@ func (r *FloatTreeReader) Value() interface{} {
 	return r.Double()
 }
 
+func (r *FloatTreeReader) seek(p *positionProvider) error {
+	if err := r.BaseTreeReader.seek(p); err != nil {
+		return err
+	}
+	return seekReader(r.Reader, p)
+}
+
 func (r *FloatTreeReader) Err() error {
 	if r.err != nil {
 		return r.err
@@ -775,7 +927,7 @@ func (r *BinaryTreeReader) Next() bool {
 func (r *BinaryTreeReader) Binary() []byte {
 	l := int(r.length.Int())
 	b := make([]byte, l, l)
-	n, err := r.data.Read(b)
+	n, err := io.ReadFull(r.data, b)
 	if err != nil {
 		r.err = err
 	} else if n != l {
@@ -791,6 +943,16 @@ func (r *BinaryTreeReader) Value() interface{} {
 	return r.Binary()
 }
 
+func (r *BinaryTreeReader) seek(p *positionProvider) error {
+	if err := r.BaseTreeReader.seek(p); err != nil {
+		return err
+	}
+	if err := seekReader(r.data, p); err != nil {
+		return err
+	}
+	return seekReader(r.length, p)
+}
+
 func (r *BinaryTreeReader) Err() error {
 	if r.err != nil {
 		return r.err
@@ -825,7 +987,7 @@ type UnionTreeReader struct {
 func NewUnionTreeReader(present, data io.Reader, children []TreeReader) (*UnionTreeReader, error) {
 	return &UnionTreeReader{
 		BaseTreeReader: NewBaseTreeReader(present),
-		data:           NewRunLengthByteReader(bufio.NewReader(data)),
+		data:           NewRunLengthByteReader(byteReader(data)),
 		children:       children,
 	}, nil
 }
@@ -864,6 +1026,15 @@ func (u *UnionTreeReader) Value() interface{} {
 	return fmt.Errorf("no value available in union child column: %v", i)
 }
 
+// seek seeks the union column to the positions provided by p. The child columns
+// are seeked separately using their own positions.
+func (u *UnionTreeReader) seek(p *positionProvider) error {
+	if err := u.BaseTreeReader.seek(p); err != nil {
+		return err
+	}
+	return u.data.seek(p)
+}
+
 // Err returns the last error to have occurred.
 func (u *UnionTreeReader) Err() error {
 	if u.err != nil {
@@ -896,7 +1067,7 @@ func NewDecimalTreeReader(present, data, secondary io.Reader, encoding *proto.Co
 	}
 	return &DecimalTreeReader{
 		BaseTreeReader: NewBaseTreeReader(present),
-		data:           bufio.NewReader(data),
+		data:           byteReader(data),
 		secondary:      ireader,
 		precision:      precision,
 		scale:          scale,
@@ -936,6 +1107,16 @@ func (d *DecimalTreeReader) Value() interface{} {
 	return d.Decimal()
 }
 
+func (d *DecimalTreeReader) seek(p *positionProvider) error {
+	if err := d.BaseTreeReader.seek(p); err != nil {
+		return err
+	}
+	if err := seekReader(d.data, p); err != nil {
+		return err
+	}
+	return seekReader(d.secondary, p)
+}
+
 // Err returns the last error to have occurred.
 func (d *DecimalTreeReader) Err() error {
 	if d.err != nil {
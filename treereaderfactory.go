@@ -2,11 +2,24 @@ package orc
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/scritchley/orc/proto"
 )
 
-func createTreeReader(schema *TypeDescription, s *Stripe) (TreeReader, error) {
+// createTreeReader returns the TreeReader for the column described by schema,
+// adding it and the TreeReaders of any child columns to readers by column ID.
+// Timestamps are returned in location.
+func createTreeReader(schema *TypeDescription, s *Stripe, readers map[int]TreeReader, trimCharPadding bool, location *time.Location) (TreeReader, error) {
+	reader, err := newTreeReader(schema, s, readers, trimCharPadding, location)
+	if err != nil {
+		return nil, err
+	}
+	readers[schema.getID()] = reader
+	return reader, nil
+}
+
+func newTreeReader(schema *TypeDescription, s *Stripe, readers map[int]TreeReader, trimCharPadding bool, location *time.Location) (TreeReader, error) {
 	id := schema.getID()
 	encoding, err := s.getColumn(id)
 	if err != nil {
@@ -45,7 +58,19 @@ func createTreeReader(schema *TypeDescription, s *Stripe) (TreeReader, error) {
 			s.get(streamName{id, proto.Stream_DATA}),
 			encoding,
 		)
-	case CategoryString, CategoryVarchar, CategoryChar:
+	case CategoryChar:
+		reader, err := NewStringTreeReader(
+			s.get(streamName{id, proto.Stream_PRESENT}),
+			s.get(streamName{id, proto.Stream_DATA}),
+			s.get(streamName{id, proto.Stream_LENGTH}),
+			s.get(streamName{id, proto.Stream_DICTIONARY_DATA}),
+			encoding,
+		)
+		if err != nil || !trimCharPadding {
+			return reader, err
+		}
+		return NewCharTreeReader(reader), nil
+	case CategoryString, CategoryVarchar:
 		return NewStringTreeReader(
 			s.get(streamName{id, proto.Stream_PRESENT}),
 			s.get(streamName{id, proto.Stream_DATA}),
@@ -59,13 +84,22 @@ func createTreeReader(schema *TypeDescription, s *Stripe) (TreeReader, error) {
 			s.get(streamName{id, proto.Stream_DATA}),
 			encoding,
 		)
-	case CategoryTimestamp:
-		return NewTimestampTreeReader(
+	case CategoryTimestamp, CategoryTimestampInstant:
+		reader, err := NewTimestampTreeReader(
 			s.get(streamName{id, proto.Stream_PRESENT}),
 			s.get(streamName{id, proto.Stream_DATA}),
 			s.get(streamName{id, proto.Stream_SECONDARY}),
 			encoding,
 		)
+		if err != nil {
+			return nil, err
+		}
+		// Timestamps with local time zone are instants written in UTC.
+		if category == CategoryTimestamp {
+			reader.base = timestampBase(s.writerTimezone)
+		}
+		reader.location = location
+		return reader, nil
 	case CategoryBinary:
 		return NewBinaryTreeReader(
 			s.get(streamName{id, proto.Stream_PRESENT}),
@@ -86,7 +120,7 @@ func createTreeReader(schema *TypeDescription, s *Stripe) (TreeReader, error) {
 		if len(schema.children) != 1 {
 			return nil, fmt.Errorf("expect 1 child for list type, got: %v", len(schema.children))
 		}
-		valueReader, err := createTreeReader(schema.children[0], s)
+		valueReader, err := createTreeReader(schema.children[0], s, readers, trimCharPadding, location)
 		if err != nil {
 			return nil, err
 		}
@@ -100,11 +134,11 @@ func createTreeReader(schema *TypeDescription, s *Stripe) (TreeReader, error) {
 		if len(schema.children) != 2 {
 			return nil, fmt.Errorf("expect 2 children for map type, got: %v", len(schema.children))
 		}
-		keyReader, err := createTreeReader(schema.children[0], s)
+		keyReader, err := createTreeReader(schema.children[0], s, readers, trimCharPadding, location)
 		if err != nil {
 			return nil, err
 		}
-		valueReader, err := createTreeReader(schema.children[1], s)
+		valueReader, err := createTreeReader(schema.children[1], s, readers, trimCharPadding, location)
 		if err != nil {
 			return nil, err
 		}
@@ -118,7 +152,7 @@ func createTreeReader(schema *TypeDescription, s *Stripe) (TreeReader, error) {
 	case CategoryStruct:
 		children := make(map[string]TreeReader)
 		for i := range schema.children {
-			child, err := createTreeReader(schema.children[i], s)
+			child, err := createTreeReader(schema.children[i], s, readers, trimCharPadding, location)
 			if err != nil {
 				return nil, err
 			}
@@ -131,7 +165,7 @@ func createTreeReader(schema *TypeDescription, s *Stripe) (TreeReader, error) {
 	case CategoryUnion:
 		children := make([]TreeReader, len(schema.children))
 		for i := range schema.children {
-			child, err := createTreeReader(schema.children[i], s)
+			child, err := createTreeReader(schema.children[i], s, readers, trimCharPadding, location)
 			if err != nil {
 				return nil, err
 			}
@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"math/big"
 	"reflect"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/scritchley/orc/proto"
 )
@@ -27,12 +30,51 @@ type TreeWriter interface {
 	Streams() []Stream
 	// RowIndex returns the RowIndex for the writer.
 	RowIndex() *proto.RowIndex
+	// BloomFilterIndex returns the BloomFilterIndex for the writer, or nil if
+	// bloom filters are not enabled.
+	BloomFilterIndex() *proto.BloomFilterIndex
+	// EnableBloomFilter enables writing a bloom filter for each row index stride.
+	EnableBloomFilter(expectedEntries int64, fpp float64)
 	// RecordPositions
 	RecordPositions()
 	// Statistics
 	Statistics() ColumnStatistics
 }
 
+// columnCloser is implemented by the TreeWriters of compound types, whose Close
+// method also closes the TreeWriters of their children.
+type columnCloser interface {
+	// closeColumn closes the streams of the TreeWriter without closing the
+	// TreeWriters of its children.
+	closeColumn() error
+}
+
+// closeColumn closes the streams of the TreeWriter t without closing the
+// TreeWriters of any children.
+func closeColumn(t TreeWriter) error {
+	if c, ok := t.(columnCloser); ok {
+		return c.closeColumn()
+	}
+	return t.Close()
+}
+
+// vectorWriter is implemented by TreeWriters that can write the values of a
+// ColumnVector without converting each of them to an interface{}.
+type vectorWriter interface {
+	// writeVector writes the values of v from index start up to end.
+	writeVector(v ColumnVector, start, end int) error
+}
+
+// writeVector writes the values of v from index start up to end to the
+// TreeWriter t.
+func writeVector(t TreeWriter, v ColumnVector, start, end int) error {
+	w, ok := t.(vectorWriter)
+	if !ok {
+		return fmt.Errorf("cannot write column vectors to %T", t)
+	}
+	return w.writeVector(v, start, end)
+}
+
 // BaseTreeWriter is a TreeWriter implementation that writes to the present stream. It
 // is the basis for all other TreeWriter implementations.
 type BaseTreeWriter struct {
@@ -44,8 +86,12 @@ type BaseTreeWriter struct {
 	statistics        ColumnStatistics
 	positionRecorders PositionRecorders
 	indexEntries      []*proto.RowIndexEntry
+	bloomFilter       *BloomFilter
+	bloomFilters      []*proto.BloomFilter
+	bloomFilterFpp    float64
+	expectedEntries   int64
 	streams           []Stream
-	numValues         uint64
+	rowGroupPositions []uint64
 	hasNull           bool
 }
 
@@ -60,8 +106,8 @@ func NewBaseTreeWriter(category Category, codec CompressionCodec) BaseTreeWriter
 		positionRecorders: make(PositionRecorders, 0),
 	}
 	present := b.AddStream(proto.Stream_PRESENT.Enum())
-	b.AddPositionRecorder(present)
 	b.present = NewBooleanWriter(present.buffer)
+	b.AddPositionRecorder(b.present)
 	b.buffer = present.buffer
 	return b
 }
@@ -69,26 +115,39 @@ func NewBaseTreeWriter(category Category, codec CompressionCodec) BaseTreeWriter
 func (b *BaseTreeWriter) positions() []uint64 {
 	var positions []uint64
 	for _, recorder := range b.positionRecorders {
-		switch b.codec.(type) {
-		case CompressionNone:
-			positions = append(positions, recorder.Positions()...)
-		default:
-			//TODO: Check if this is correct
-			positions = append(positions, recorder.Positions()...)
-		}
-	}
-	if !b.hasNull {
-		return positions[1:]
+		positions = append(positions, recorder.Positions()...)
 	}
 	return positions
 }
 
+// RecordPositions completes the index entry for the current row group using
+// the positions recorded at its start, and records the positions at which the
+// next row group starts.
 func (b *BaseTreeWriter) RecordPositions() {
+	positions := b.positions()
+	start := b.rowGroupPositions
+	if start == nil {
+		// The first row group starts at the beginning of each stream.
+		start = make([]uint64, len(positions))
+	}
 	b.indexEntries = append(b.indexEntries, &proto.RowIndexEntry{
-		Positions:  b.positions(),
+		Positions:  start,
 		Statistics: b.currentStatistics.Statistics(),
 	})
+	b.rowGroupPositions = positions
 	b.currentStatistics = NewColumnStatistics(b.category)
+	if b.bloomFilter != nil {
+		b.bloomFilters = append(b.bloomFilters, b.bloomFilter.Proto())
+		b.bloomFilter = NewBloomFilter(b.expectedEntries, b.bloomFilterFpp)
+	}
+}
+
+// EnableBloomFilter enables writing a bloom filter with a false positive
+// probability of fpp for each row index stride of expectedEntries rows.
+func (b *BaseTreeWriter) EnableBloomFilter(expectedEntries int64, fpp float64) {
+	b.expectedEntries = expectedEntries
+	b.bloomFilterFpp = fpp
+	b.bloomFilter = NewBloomFilter(expectedEntries, fpp)
 }
 
 // Write checks whether i is nil and writes an appropriate true or false value to
@@ -97,45 +156,128 @@ func (b *BaseTreeWriter) Write(i interface{}) error {
 	// Add the value to the statistics
 	b.statistics.Add(i)
 	b.currentStatistics.Add(i)
-	// If no nulls have been received yet, increment the numValues count.
-	if !b.hasNull {
-		b.numValues++
+	if b.bloomFilter != nil {
+		b.bloomFilter.add(b.category, i)
 	}
 	// isPresent is optional, therefore, support nil BooleanWriter
 	if b.present == nil {
 		return nil
 	}
+	// The present stream is always written so that the positions recorded for
+	// it are valid, it is discarded on Close if the column has no nulls.
 	if i == nil {
-		// The stream has nulls, therefore, set hasNull to
-		// true and write the prior values to the stream.
 		b.hasNull = true
-		for j := uint64(1); j < b.numValues; j++ {
-			err := b.present.WriteBool(true)
-			if err != nil {
+	}
+	return b.present.WriteBool(i != nil)
+}
+
+// writeNull writes a null value to the present stream and the statistics.
+func (b *BaseTreeWriter) writeNull() error {
+	return b.Write(nil)
+}
+
+// writePresent writes a true value to the present stream.
+func (b *BaseTreeWriter) writePresent() error {
+	if b.present == nil {
+		return nil
+	}
+	return b.present.WriteBool(true)
+}
+
+// writeValue counts a value in the statistics and writes a true value to the
+// present stream. It is used by compound columns, whose statistics do not
+// depend on their values.
+func (b *BaseTreeWriter) writeValue() error {
+	countValue(b.statistics)
+	countValue(b.currentStatistics)
+	return b.writePresent()
+}
+
+// writeInt adds the integer value to the statistics and bloom filter without
+// converting it to an interface{}, and writes a true value to the present
+// stream.
+func (b *BaseTreeWriter) writeInt(value int64) error {
+	addInt(b.statistics, value)
+	addInt(b.currentStatistics, value)
+	if b.bloomFilter != nil {
+		b.bloomFilter.addHash(b.bloomFilter.intHash(value))
+	}
+	return b.writePresent()
+}
+
+// writeInts adds the integer values to the statistics as a slice and to the
+// bloom filter, and writes a true value to the present stream for each.
+func (b *BaseTreeWriter) writeInts(values []int64) error {
+	addInts(b.statistics, values)
+	addInts(b.currentStatistics, values)
+	if b.bloomFilter != nil {
+		for _, value := range values {
+			b.bloomFilter.addHash(b.bloomFilter.intHash(value))
+		}
+	}
+	return b.writePresents(len(values))
+}
+
+// writePresents writes n true values to the present stream.
+func (b *BaseTreeWriter) writePresents(n int) error {
+	if b.present == nil {
+		return nil
+	}
+	for i := 0; i < n; i++ {
+		if err := b.present.WriteBool(true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeRuns writes each null of a vector from index start up to end to the
+// present stream and statistics, and calls fn with the bounds of each run of
+// values between them that are not null.
+func (b *BaseTreeWriter) writeRuns(isNull func(i int) bool, start, end int, fn func(from, to int) error) error {
+	from := start
+	for i := start; i < end; i++ {
+		if !isNull(i) {
+			continue
+		}
+		if from < i {
+			if err := fn(from, i); err != nil {
 				return err
 			}
 		}
-		b.numValues = 0
-		// If interface value is nil, then write false to isPresent stream.
-		return b.present.WriteBool(false)
+		if err := b.writeNull(); err != nil {
+			return err
+		}
+		from = i + 1
 	}
-	if b.hasNull {
-		// Write to the stream only if it has nulls.
-		return b.present.WriteBool(true)
+	if from < end {
+		return fn(from, end)
 	}
 	return nil
 }
 
+// writeDouble adds the floating point value to the statistics and bloom filter
+// without converting it to an interface{}, and writes a true value to the
+// present stream.
+func (b *BaseTreeWriter) writeDouble(value float64) error {
+	addDouble(b.statistics, value)
+	addDouble(b.currentStatistics, value)
+	if b.bloomFilter != nil {
+		b.bloomFilter.addHash(b.bloomFilter.floatHash(value))
+	}
+	return b.writePresent()
+}
+
 // Close flushes the underlying BufferedWriter returning an error if one occurs.
 func (b *BaseTreeWriter) Close() error {
 	if err := b.present.Close(); err != nil {
 		return err
 	}
-	// If the column has no nulls then reset the
+	// If the column has no nulls then discard the
 	// underlying buffer.
-	if !b.statistics.Statistics().GetHasNull() {
+	if !b.hasNull {
 		b.buffer.Reset()
-		b.buffer.written = 0
+		return nil
 	}
 	return b.buffer.Close()
 }
@@ -173,12 +315,34 @@ func (b *BaseTreeWriter) Streams() []Stream {
 	return b.streams
 }
 
+// RowIndex returns the RowIndex for the writer. The positions of the present
+// stream are removed from each entry if the column has no nulls, as the stream
+// is not written.
 func (b *BaseTreeWriter) RowIndex() *proto.RowIndex {
-	if !b.category.isPrimitive {
-		return nil
+	if b.hasNull {
+		return &proto.RowIndex{
+			Entry: b.indexEntries,
+		}
+	}
+	n := len(b.present.Positions())
+	entries := make([]*proto.RowIndexEntry, len(b.indexEntries))
+	for i, entry := range b.indexEntries {
+		entries[i] = &proto.RowIndexEntry{
+			Positions:  entry.Positions[n:],
+			Statistics: entry.Statistics,
+		}
 	}
 	return &proto.RowIndex{
-		Entry: b.indexEntries,
+		Entry: entries,
+	}
+}
+
+func (b *BaseTreeWriter) BloomFilterIndex() *proto.BloomFilterIndex {
+	if b.bloomFilter == nil {
+		return nil
+	}
+	return &proto.BloomFilterIndex{
+		BloomFilter: b.bloomFilters,
 	}
 }
 
@@ -188,7 +352,10 @@ func (b *BaseTreeWriter) Statistics() ColumnStatistics {
 
 // IntegerWriter is an interface implemented by all integer type writers.
 type IntegerWriter interface {
+	PositionRecorder
 	WriteInt(value int64) error
+	// WriteInts writes each of the values in turn.
+	WriteInts(values []int64) error
 	Close() error
 	Flush() error
 }
@@ -216,13 +383,13 @@ type IntegerTreeWriter struct {
 func NewIntegerTreeWriter(category Category, codec CompressionCodec) (*IntegerTreeWriter, error) {
 	base := NewBaseTreeWriter(category, codec)
 	data := base.AddStream(proto.Stream_DATA.Enum())
-	base.AddPositionRecorder(data)
 	// TODO: Inherit column encoding kind from orc.Writer ORC file version.
 	columnEncoding := proto.ColumnEncoding_DIRECT_V2
 	iwriter, err := createIntegerWriter(columnEncoding, data.buffer, true)
 	if err != nil {
 		return nil, err
 	}
+	base.AddPositionRecorder(iwriter)
 	return &IntegerTreeWriter{
 		BaseTreeWriter: base,
 		IntegerWriter:  iwriter,
@@ -274,6 +441,21 @@ func (w *IntegerTreeWriter) Write(value interface{}) error {
 	}
 }
 
+// writeVector writes the values of a LongVector from index start up to end.
+func (w *IntegerTreeWriter) writeVector(v ColumnVector, start, end int) error {
+	vec, ok := v.(*LongVector)
+	if !ok {
+		return fmt.Errorf("cannot write %T to integer column type", v)
+	}
+	return w.BaseTreeWriter.writeRuns(vec.IsNull, start, end, func(from, to int) error {
+		values := vec.Values[from:to]
+		if err := w.BaseTreeWriter.writeInts(values); err != nil {
+			return err
+		}
+		return w.IntegerWriter.WriteInts(values)
+	})
+}
+
 // Close closes the underlying writers returning an error if one occurs.
 func (w *IntegerTreeWriter) Close() error {
 	if err := w.BaseTreeWriter.Close(); err != nil {
@@ -323,6 +505,10 @@ func (s *StructTreeWriter) Write(value interface{}) error {
 	if err := s.BaseTreeWriter.Write(value); err != nil {
 		return err
 	}
+	if value == nil {
+		// The values of the fields of null structs are not written.
+		return nil
+	}
 	values, ok := value.([]interface{})
 	if !ok {
 		return fmt.Errorf("wrong type for struct tree reader, expected: %T, got: %T", []interface{}{}, value)
@@ -339,10 +525,53 @@ func (s *StructTreeWriter) Write(value interface{}) error {
 	return nil
 }
 
+// writeVector writes the values of a StructVector from index start up to end.
+// The values of the fields of null structs are not written.
+func (s *StructTreeWriter) writeVector(v ColumnVector, start, end int) error {
+	vec, ok := v.(*StructVector)
+	if !ok {
+		return fmt.Errorf("cannot write %T to struct column type", v)
+	}
+	if len(vec.Fields) != len(s.children) {
+		return fmt.Errorf("wrong number of fields, expected: %v, got: %v", len(s.children), len(vec.Fields))
+	}
+	// Write the fields of each run of structs that are not null.
+	from := start
+	for i := start; i < end; i++ {
+		if !vec.IsNull(i) {
+			if err := s.BaseTreeWriter.writeValue(); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := s.writeFields(vec, from, i); err != nil {
+			return err
+		}
+		from = i + 1
+		if err := s.BaseTreeWriter.writeNull(); err != nil {
+			return err
+		}
+	}
+	return s.writeFields(vec, from, end)
+}
+
+// writeFields writes the values of the fields of vec from index start up to end.
+func (s *StructTreeWriter) writeFields(vec *StructVector, start, end int) error {
+	if start == end {
+		return nil
+	}
+	for i, child := range s.children {
+		if err := writeVector(child, vec.Fields[i], start, end); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Close closes the StructTreeWriter and its child TreeWriters returning an
 // error if one occurs.
 func (s *StructTreeWriter) Close() error {
-	if err := s.BaseTreeWriter.Close(); err != nil {
+	if err := s.closeColumn(); err != nil {
 		return err
 	}
 	for i := range s.children {
@@ -354,6 +583,12 @@ func (s *StructTreeWriter) Close() error {
 	return nil
 }
 
+// closeColumn closes the streams of the StructTreeWriter without closing its
+// child TreeWriters.
+func (s *StructTreeWriter) closeColumn() error {
+	return s.BaseTreeWriter.Close()
+}
+
 // Flush flushes the StructTreeWriter and its child TreeWriters returning an
 // error if one occurs.
 func (s *StructTreeWriter) Flush() error {
@@ -392,10 +627,11 @@ type BooleanTreeWriter struct {
 func NewBooleanTreeWriter(category Category, codec CompressionCodec) (*BooleanTreeWriter, error) {
 	base := NewBaseTreeWriter(category, codec)
 	data := base.AddStream(proto.Stream_DATA.Enum())
-	base.AddPositionRecorder(data)
+	booleanWriter := NewBooleanWriter(data.buffer)
+	base.AddPositionRecorder(booleanWriter)
 	return &BooleanTreeWriter{
 		BaseTreeWriter: base,
-		BooleanWriter:  NewBooleanWriter(data.buffer),
+		BooleanWriter:  booleanWriter,
 		BufferedWriter: data.buffer,
 	}, nil
 }
@@ -405,7 +641,7 @@ func (b *BooleanTreeWriter) Write(value interface{}) error {
 		return b.BaseTreeWriter.Write(value)
 	}
 	if bv, ok := value.(bool); ok {
-		if err := b.BaseTreeWriter.Write(true); err != nil {
+		if err := b.BaseTreeWriter.Write(bv); err != nil {
 			return err
 		}
 		return b.BooleanWriter.WriteBool(bv)
@@ -413,6 +649,29 @@ func (b *BooleanTreeWriter) Write(value interface{}) error {
 	return fmt.Errorf("expected bool or nil value, received %T", value)
 }
 
+// writeVector writes the values of a BooleanVector from index start up to end.
+func (b *BooleanTreeWriter) writeVector(v ColumnVector, start, end int) error {
+	vec, ok := v.(*BooleanVector)
+	if !ok {
+		return fmt.Errorf("cannot write %T to boolean column type", v)
+	}
+	for i := start; i < end; i++ {
+		if vec.IsNull(i) {
+			if err := b.BaseTreeWriter.writeNull(); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := b.BaseTreeWriter.Write(vec.Values[i]); err != nil {
+			return err
+		}
+		if err := b.BooleanWriter.WriteBool(vec.Values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (b *BooleanTreeWriter) Close() error {
 	if err := b.BaseTreeWriter.Close(); err != nil {
 		return err
@@ -439,6 +698,104 @@ func (b *BooleanTreeWriter) Encoding() *proto.ColumnEncoding {
 	}
 }
 
+// ByteTreeWriter is a TreeWriter implementation that writes a tinyint column type.
+type ByteTreeWriter struct {
+	BaseTreeWriter
+	*RunLengthByteWriter
+	*BufferedWriter
+}
+
+// NewByteTreeWriter returns a new ByteTreeWriter or an error if one occurs.
+func NewByteTreeWriter(category Category, codec CompressionCodec) (*ByteTreeWriter, error) {
+	base := NewBaseTreeWriter(category, codec)
+	data := base.AddStream(proto.Stream_DATA.Enum())
+	byteWriter := NewRunLengthByteWriter(data.buffer)
+	base.AddPositionRecorder(byteWriter)
+	return &ByteTreeWriter{
+		BaseTreeWriter:      base,
+		RunLengthByteWriter: byteWriter,
+		BufferedWriter:      data.buffer,
+	}, nil
+}
+
+// Write writes a value returning an error if one occurs. It accepts a byte, int8
+// or int value, or a nil value for writing nulls to the stream. Any other types
+// will return an error.
+func (b *ByteTreeWriter) Write(value interface{}) error {
+	var byt byte
+	switch t := value.(type) {
+	case nil:
+		return b.BaseTreeWriter.Write(value)
+	case byte:
+		byt = t
+	case int8:
+		byt = byte(t)
+	case int:
+		if t < math.MinInt8 || t > math.MaxInt8 {
+			return fmt.Errorf("value %v out of range for tinyint column type", t)
+		}
+		byt = byte(t)
+	default:
+		return fmt.Errorf("cannot write %T to tinyint column type", t)
+	}
+	// Statistics are recorded using the signed value as returned by the ByteTreeReader.
+	if err := b.BaseTreeWriter.Write(int64(int8(byt))); err != nil {
+		return err
+	}
+	return b.RunLengthByteWriter.WriteByte(byt)
+}
+
+// writeVector writes the values of a LongVector from index start up to end.
+func (b *ByteTreeWriter) writeVector(v ColumnVector, start, end int) error {
+	vec, ok := v.(*LongVector)
+	if !ok {
+		return fmt.Errorf("cannot write %T to tinyint column type", v)
+	}
+	return b.BaseTreeWriter.writeRuns(vec.IsNull, start, end, func(from, to int) error {
+		values := vec.Values[from:to]
+		byts := make([]byte, len(values))
+		for i, value := range values {
+			if value < math.MinInt8 || value > math.MaxInt8 {
+				return fmt.Errorf("value %v out of range for tinyint column type", value)
+			}
+			byts[i] = byte(value)
+		}
+		if err := b.BaseTreeWriter.writeInts(values); err != nil {
+			return err
+		}
+		return b.RunLengthByteWriter.WriteBytes(byts)
+	})
+}
+
+// Close closes the underlying writers returning an error if one occurs.
+func (b *ByteTreeWriter) Close() error {
+	if err := b.BaseTreeWriter.Close(); err != nil {
+		return err
+	}
+	if err := b.RunLengthByteWriter.Close(); err != nil {
+		return err
+	}
+	return b.BufferedWriter.Close()
+}
+
+// Flush flushes the underlying writers returning an error if one occurs.
+func (b *ByteTreeWriter) Flush() error {
+	if err := b.BaseTreeWriter.Flush(); err != nil {
+		return err
+	}
+	if err := b.RunLengthByteWriter.Flush(); err != nil {
+		return err
+	}
+	return b.BufferedWriter.Flush()
+}
+
+// Encoding returns the column encoding used for the ByteTreeWriter.
+func (b *ByteTreeWriter) Encoding() *proto.ColumnEncoding {
+	return &proto.ColumnEncoding{
+		Kind: proto.ColumnEncoding_DIRECT.Enum(),
+	}
+}
+
 // FloatTreeWriter is a TreeWriter that writes to a Float or Double column type.
 type FloatTreeWriter struct {
 	BaseTreeWriter
@@ -510,6 +867,32 @@ func (f *FloatTreeWriter) WriteFloat(value interface{}) error {
 	return nil
 }
 
+// writeVector writes the values of a DoubleVector from index start up to end.
+func (f *FloatTreeWriter) writeVector(v ColumnVector, start, end int) error {
+	vec, ok := v.(*DoubleVector)
+	if !ok {
+		return fmt.Errorf("cannot write %T to %s column type", v, f.category)
+	}
+	return f.BaseTreeWriter.writeRuns(vec.IsNull, start, end, func(from, to int) error {
+		byt := make([]byte, f.bytesPerValue*(to-from))
+		for i, value := range vec.Values[from:to] {
+			if f.bytesPerValue == 8 {
+				binary.LittleEndian.PutUint64(byt[i*8:], math.Float64bits(value))
+			} else {
+				// Float values are added to the statistics and bloom filter
+				// as the float32 value written.
+				value = float64(float32(value))
+				binary.LittleEndian.PutUint32(byt[i*4:], math.Float32bits(float32(value)))
+			}
+			if err := f.BaseTreeWriter.writeDouble(value); err != nil {
+				return err
+			}
+		}
+		_, err := f.BufferedWriter.Write(byt)
+		return err
+	})
+}
+
 func (f *FloatTreeWriter) Close() error {
 	if err := f.BaseTreeWriter.Close(); err != nil {
 		return err
@@ -549,6 +932,8 @@ type StringTreeWriter struct {
 	dictionaryEncodedData IntegerWriter
 	dictionary            *DictionaryV2
 	bufferedValues        []string
+	rowGroupValues        []int
+	rowGroup              int
 	numValues             int
 	modeSelected          bool
 	isDictionaryEncoded   bool
@@ -559,9 +944,7 @@ type StringTreeWriter struct {
 func NewStringTreeWriter(category Category, codec CompressionCodec) (*StringTreeWriter, error) {
 	base := NewBaseTreeWriter(category, codec)
 	data := base.AddStream(proto.Stream_DATA.Enum())
-	base.AddPositionRecorder(data)
 	lengths := base.AddStream(proto.Stream_LENGTH.Enum())
-	base.AddPositionRecorder(lengths)
 	s := &StringTreeWriter{
 		BaseTreeWriter: base,
 		data:           data.buffer,
@@ -595,10 +978,67 @@ func (s *StringTreeWriter) Write(value interface{}) error {
 	return fmt.Errorf("expected string value, received: %T", value)
 }
 
+// writeVector writes the values of a BytesVector from index start up to end.
+func (s *StringTreeWriter) writeVector(v ColumnVector, start, end int) error {
+	vec, ok := v.(*BytesVector)
+	if !ok {
+		return fmt.Errorf("cannot write %T to %s column type", v, s.category)
+	}
+	for i := start; i < end; i++ {
+		if vec.IsNull(i) {
+			if err := s.BaseTreeWriter.writeNull(); err != nil {
+				return err
+			}
+			continue
+		}
+		str := string(vec.Bytes(i))
+		if err := s.BaseTreeWriter.Write(str); err != nil {
+			return err
+		}
+		if err := s.WriteString(str); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *StringTreeWriter) Flush() error {
 	return nil
 }
 
+// RecordPositions records the index entry for the current row group. As values
+// are buffered until the encoding is selected, the positions of the data and
+// length streams are added to each entry once the values are written.
+func (s *StringTreeWriter) RecordPositions() {
+	s.BaseTreeWriter.RecordPositions()
+	s.rowGroupValues = append(s.rowGroupValues, len(s.bufferedValues))
+}
+
+// recordValuePositions adds the current positions of the data and length
+// streams to the index entries of any row groups that start at or before the
+// buffered value i.
+func (s *StringTreeWriter) recordValuePositions(i int) {
+	for ; s.rowGroup < len(s.indexEntries); s.rowGroup++ {
+		// Each row group starts with the values buffered by the end of the
+		// previous row group.
+		var start int
+		if s.rowGroup > 0 {
+			start = s.rowGroupValues[s.rowGroup-1]
+		}
+		if start > i {
+			return
+		}
+		var positions []uint64
+		if s.isDictionaryEncoded {
+			positions = s.dictionaryEncodedData.Positions()
+		} else {
+			positions = append(s.data.Positions(), s.lengthsIntWriter.Positions()...)
+		}
+		entry := s.indexEntries[s.rowGroup]
+		entry.Positions = append(entry.Positions, positions...)
+	}
+}
+
 // Close closes the underlying writes returning an error if one occurs.
 func (s *StringTreeWriter) Close() error {
 	if err := s.flushBufferedValues(); err != nil {
@@ -628,7 +1068,6 @@ func (s *StringTreeWriter) flushDictionaryValues() error {
 	var err error
 	// Flush the dictionary data itself to the dictionary data stream.
 	dictionaryData := s.BaseTreeWriter.AddStream(proto.Stream_DICTIONARY_DATA.Enum())
-	s.AddPositionRecorder(dictionaryData)
 	s.dictionaryData = dictionaryData.buffer
 	// Create an IntegerWriter for the dictionary encoded column and write the buffered values.
 	s.dictionaryEncodedData, err = createIntegerWriter(proto.ColumnEncoding_DICTIONARY_V2, s.data, false)
@@ -651,7 +1090,8 @@ func (s *StringTreeWriter) flushDictionaryValues() error {
 	if err != nil {
 		return err
 	}
-	for _, value := range s.bufferedValues {
+	for j, value := range s.bufferedValues {
+		s.recordValuePositions(j)
 		i, ok := s.dictionary.get(value)
 		if !ok {
 			return fmt.Errorf("value: %s not found in dictionary", value)
@@ -661,6 +1101,7 @@ func (s *StringTreeWriter) flushDictionaryValues() error {
 			return err
 		}
 	}
+	s.recordValuePositions(len(s.bufferedValues))
 	// Finally reset to the buffered values and dictionary ready for the next stripe.
 	s.bufferedValues = nil
 	s.numValues = 0
@@ -675,7 +1116,8 @@ func (s *StringTreeWriter) flushDirectValues() error {
 	if err != nil {
 		return err
 	}
-	for _, value := range s.bufferedValues {
+	for i, value := range s.bufferedValues {
+		s.recordValuePositions(i)
 		_, err := s.data.Write([]byte(value))
 		if err != nil {
 			return err
@@ -685,6 +1127,7 @@ func (s *StringTreeWriter) flushDirectValues() error {
 			return err
 		}
 	}
+	s.recordValuePositions(len(s.bufferedValues))
 	return nil
 }
 
@@ -719,6 +1162,310 @@ func (s *StringTreeWriter) Encoding() *proto.ColumnEncoding {
 	}
 }
 
+// CharTreeWriter is a TreeWriter implementation that writes to a char or varchar type
+// column. Values longer than the maximum length of the column are either truncated or
+// rejected, and char values are right-padded with spaces to the maximum length.
+type CharTreeWriter struct {
+	*StringTreeWriter
+	maxLength int
+	truncate  bool
+	pad       bool
+}
+
+// NewCharTreeWriter returns a new CharTreeWriter or an error if one occurs.
+func NewCharTreeWriter(category Category, codec CompressionCodec, maxLength int, truncate bool) (*CharTreeWriter, error) {
+	s, err := NewStringTreeWriter(category, codec)
+	if err != nil {
+		return nil, err
+	}
+	return &CharTreeWriter{
+		StringTreeWriter: s,
+		maxLength:        maxLength,
+		truncate:         truncate,
+		pad:              category == CategoryChar,
+	}, nil
+}
+
+// Write writes the provided value to the underlying writers. It returns an
+// error if the value is not a string type, if the value exceeds the maximum
+// length and truncation is disabled, or if an error occurs during writing.
+func (c *CharTreeWriter) Write(value interface{}) error {
+	str, ok := value.(string)
+	if !ok {
+		return c.StringTreeWriter.Write(value)
+	}
+	if n := utf8.RuneCountInString(str); n > c.maxLength {
+		if !c.truncate {
+			return fmt.Errorf("value of length %v exceeds maximum length %v for %s column type", n, c.maxLength, c.category)
+		}
+		str = string([]rune(str)[:c.maxLength])
+	} else if c.pad && n < c.maxLength {
+		str += strings.Repeat(" ", c.maxLength-n)
+	}
+	return c.StringTreeWriter.Write(str)
+}
+
+// writeVector writes the values of a BytesVector from index start up to end,
+// truncating or padding them as Write does.
+func (c *CharTreeWriter) writeVector(v ColumnVector, start, end int) error {
+	vec, ok := v.(*BytesVector)
+	if !ok {
+		return fmt.Errorf("cannot write %T to %s column type", v, c.category)
+	}
+	for i := start; i < end; i++ {
+		if vec.IsNull(i) {
+			if err := c.BaseTreeWriter.writeNull(); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := c.Write(string(vec.Bytes(i))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BinaryTreeWriter is a TreeWriter implementation that writes a binary column type.
+type BinaryTreeWriter struct {
+	BaseTreeWriter
+	data             *BufferedWriter
+	lengths          *BufferedWriter
+	lengthsIntWriter IntegerWriter
+}
+
+// NewBinaryTreeWriter returns a new BinaryTreeWriter or an error if one occurs.
+func NewBinaryTreeWriter(category Category, codec CompressionCodec) (*BinaryTreeWriter, error) {
+	base := NewBaseTreeWriter(category, codec)
+	data := base.AddStream(proto.Stream_DATA.Enum())
+	base.AddPositionRecorder(data)
+	lengths := base.AddStream(proto.Stream_LENGTH.Enum())
+	lengthsIntWriter, err := createIntegerWriter(proto.ColumnEncoding_DIRECT_V2, lengths.buffer, false)
+	if err != nil {
+		return nil, err
+	}
+	base.AddPositionRecorder(lengthsIntWriter)
+	return &BinaryTreeWriter{
+		BaseTreeWriter:   base,
+		data:             data.buffer,
+		lengths:          lengths.buffer,
+		lengthsIntWriter: lengthsIntWriter,
+	}, nil
+}
+
+// WriteBinary writes a byte slice to the data stream and its length to the
+// length stream, returning an error if one occurs.
+func (b *BinaryTreeWriter) WriteBinary(value []byte) error {
+	if _, err := b.data.Write(value); err != nil {
+		return err
+	}
+	return b.lengthsIntWriter.WriteInt(int64(len(value)))
+}
+
+// Write writes a value returning an error if one occurs. It accepts a []byte
+// or a nil value for writing nulls to the stream. Any other types will
+// return an error.
+func (b *BinaryTreeWriter) Write(value interface{}) error {
+	switch t := value.(type) {
+	case nil:
+		return b.BaseTreeWriter.Write(value)
+	case []byte:
+		if err := b.BaseTreeWriter.Write(t); err != nil {
+			return err
+		}
+		return b.WriteBinary(t)
+	default:
+		return fmt.Errorf("cannot write %T to binary column type", t)
+	}
+}
+
+// writeVector writes the values of a BytesVector from index start up to end.
+func (b *BinaryTreeWriter) writeVector(v ColumnVector, start, end int) error {
+	vec, ok := v.(*BytesVector)
+	if !ok {
+		return fmt.Errorf("cannot write %T to binary column type", v)
+	}
+	for i := start; i < end; i++ {
+		if vec.IsNull(i) {
+			if err := b.BaseTreeWriter.writeNull(); err != nil {
+				return err
+			}
+			continue
+		}
+		value := vec.Bytes(i)
+		if err := b.BaseTreeWriter.Write(value); err != nil {
+			return err
+		}
+		if err := b.WriteBinary(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the underlying writers returning an error if one occurs.
+func (b *BinaryTreeWriter) Close() error {
+	if err := b.BaseTreeWriter.Close(); err != nil {
+		return err
+	}
+	if err := b.lengthsIntWriter.Close(); err != nil {
+		return err
+	}
+	if err := b.lengths.Close(); err != nil {
+		return err
+	}
+	return b.data.Close()
+}
+
+// Flush flushes the underlying writers returning an error if one occurs.
+func (b *BinaryTreeWriter) Flush() error {
+	if err := b.BaseTreeWriter.Flush(); err != nil {
+		return err
+	}
+	if err := b.lengthsIntWriter.Flush(); err != nil {
+		return err
+	}
+	if err := b.lengths.Flush(); err != nil {
+		return err
+	}
+	return b.data.Flush()
+}
+
+// Encoding returns the column encoding used for the BinaryTreeWriter.
+func (b *BinaryTreeWriter) Encoding() *proto.ColumnEncoding {
+	return &proto.ColumnEncoding{
+		Kind: proto.ColumnEncoding_DIRECT_V2.Enum(),
+	}
+}
+
+// DecimalTreeWriter is a TreeWriter implementation that writes a decimal column type.
+type DecimalTreeWriter struct {
+	BaseTreeWriter
+	data               *BufferedWriter
+	secondary          *BufferedWriter
+	secondaryIntWriter IntegerWriter
+	precision          int
+	scale              int
+}
+
+// NewDecimalTreeWriter returns a new DecimalTreeWriter or an error if one occurs.
+func NewDecimalTreeWriter(category Category, codec CompressionCodec, precision, scale int) (*DecimalTreeWriter, error) {
+	base := NewBaseTreeWriter(category, codec)
+	data := base.AddStream(proto.Stream_DATA.Enum())
+	base.AddPositionRecorder(data)
+	secondary := base.AddStream(proto.Stream_SECONDARY.Enum())
+	secondaryIntWriter, err := createIntegerWriter(proto.ColumnEncoding_DIRECT_V2, secondary.buffer, true)
+	if err != nil {
+		return nil, err
+	}
+	base.AddPositionRecorder(secondaryIntWriter)
+	return &DecimalTreeWriter{
+		BaseTreeWriter:     base,
+		data:               data.buffer,
+		secondary:          secondary.buffer,
+		secondaryIntWriter: secondaryIntWriter,
+		precision:          precision,
+		scale:              scale,
+	}, nil
+}
+
+// WriteDecimal writes a Decimal value returning an error if one occurs. The value
+// must already have been adjusted to the scale of the column.
+func (d *DecimalTreeWriter) WriteDecimal(value Decimal) error {
+	if err := encodeBase128Varint(d.data, value.Int); err != nil {
+		return err
+	}
+	return d.secondaryIntWriter.WriteInt(value.Scale)
+}
+
+// Write writes a value returning an error if one occurs. It accepts a Decimal,
+// a *big.Int unscaled value using the scale of the column, a big.Rat or a nil
+// value for writing nulls to the stream. Values are rescaled to the scale of the
+// column and an error is returned if they exceed its precision.
+func (d *DecimalTreeWriter) Write(value interface{}) error {
+	var dec Decimal
+	switch t := value.(type) {
+	case nil:
+		return d.BaseTreeWriter.Write(value)
+	case Decimal:
+		if t.Int == nil {
+			return fmt.Errorf("cannot write decimal with nil value")
+		}
+		dec = t.Rescale(int64(d.scale))
+	case *big.Int:
+		dec = NewDecimal(t, int64(d.scale))
+	case big.Rat:
+		dec = decimalFromRat(&t, int64(d.scale))
+	case *big.Rat:
+		dec = decimalFromRat(t, int64(d.scale))
+	default:
+		return fmt.Errorf("cannot write %T to decimal column type", t)
+	}
+	if p := dec.Precision(); p > d.precision {
+		return fmt.Errorf("decimal value %s has precision %v which exceeds the column precision %v", dec, p, d.precision)
+	}
+	if err := d.BaseTreeWriter.Write(dec); err != nil {
+		return err
+	}
+	return d.WriteDecimal(dec)
+}
+
+// writeVector writes the values of a DecimalVector from index start up to end,
+// rescaling them as Write does.
+func (d *DecimalTreeWriter) writeVector(v ColumnVector, start, end int) error {
+	vec, ok := v.(*DecimalVector)
+	if !ok {
+		return fmt.Errorf("cannot write %T to decimal column type", v)
+	}
+	for i := start; i < end; i++ {
+		if vec.IsNull(i) {
+			if err := d.BaseTreeWriter.writeNull(); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := d.Write(vec.Values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the underlying writers returning an error if one occurs.
+func (d *DecimalTreeWriter) Close() error {
+	if err := d.BaseTreeWriter.Close(); err != nil {
+		return err
+	}
+	if err := d.secondaryIntWriter.Close(); err != nil {
+		return err
+	}
+	if err := d.secondary.Close(); err != nil {
+		return err
+	}
+	return d.data.Close()
+}
+
+// Flush flushes the underlying writers returning an error if one occurs.
+func (d *DecimalTreeWriter) Flush() error {
+	if err := d.BaseTreeWriter.Flush(); err != nil {
+		return err
+	}
+	if err := d.secondaryIntWriter.Flush(); err != nil {
+		return err
+	}
+	if err := d.secondary.Flush(); err != nil {
+		return err
+	}
+	return d.data.Flush()
+}
+
+// Encoding returns the column encoding used for the DecimalTreeWriter.
+func (d *DecimalTreeWriter) Encoding() *proto.ColumnEncoding {
+	return &proto.ColumnEncoding{
+		Kind: proto.ColumnEncoding_DIRECT_V2.Enum(),
+	}
+}
+
 type ListTreeWriter struct {
 	BaseTreeWriter
 	lengths IntegerWriter
@@ -729,13 +1476,13 @@ type ListTreeWriter struct {
 func NewListTreeWriter(category Category, codec CompressionCodec, child TreeWriter) (*ListTreeWriter, error) {
 	base := NewBaseTreeWriter(category, codec)
 	data := base.AddStream(proto.Stream_LENGTH.Enum())
-	base.AddPositionRecorder(data)
 	// TODO: Inherit column encoding kind from orc.Writer ORC file version.
 	columnEncoding := proto.ColumnEncoding_DIRECT_V2
 	iwriter, err := createIntegerWriter(columnEncoding, data.buffer, false)
 	if err != nil {
 		return nil, err
 	}
+	base.AddPositionRecorder(iwriter)
 	l := &ListTreeWriter{
 		BaseTreeWriter: base,
 		lengths:        iwriter,
@@ -771,6 +1518,43 @@ func (l *ListTreeWriter) Write(value interface{}) error {
 	}
 }
 
+// writeVector writes the values of a ListVector from index start up to end.
+// The elements of null lists are not written.
+func (l *ListTreeWriter) writeVector(v ColumnVector, start, end int) error {
+	vec, ok := v.(*ListVector)
+	if !ok {
+		return fmt.Errorf("cannot write %T to list column type", v)
+	}
+	if vec.Offsets[end] > vec.Child.Len() {
+		return fmt.Errorf("list offset %v exceeds the length of the child vector %v", vec.Offsets[end], vec.Child.Len())
+	}
+	// Write the elements of each run of lists that are not null.
+	from := vec.Offsets[start]
+	for i := start; i < end; i++ {
+		if vec.IsNull(i) {
+			if err := writeVector(l.child, vec.Child, from, vec.Offsets[i]); err != nil {
+				return err
+			}
+			from = vec.Offsets[i+1]
+			if err := l.BaseTreeWriter.writeNull(); err != nil {
+				return err
+			}
+			continue
+		}
+		n := vec.Offsets[i+1] - vec.Offsets[i]
+		if n < 0 {
+			return fmt.Errorf("invalid list offsets: %v, %v", vec.Offsets[i], vec.Offsets[i+1])
+		}
+		if err := l.BaseTreeWriter.writeValue(); err != nil {
+			return err
+		}
+		if err := l.lengths.WriteInt(int64(n)); err != nil {
+			return err
+		}
+	}
+	return writeVector(l.child, vec.Child, from, vec.Offsets[end])
+}
+
 func (l *ListTreeWriter) Flush() error {
 	if err := l.lengths.Flush(); err != nil {
 		return err
@@ -785,10 +1569,16 @@ func (l *ListTreeWriter) Flush() error {
 }
 
 func (l *ListTreeWriter) Close() error {
-	if err := l.lengths.Close(); err != nil {
+	if err := l.closeColumn(); err != nil {
 		return err
 	}
-	if err := l.child.Close(); err != nil {
+	return l.child.Close()
+}
+
+// closeColumn closes the streams of the ListTreeWriter without closing its
+// child TreeWriter.
+func (l *ListTreeWriter) closeColumn() error {
+	if err := l.lengths.Close(); err != nil {
 		return err
 	}
 	if err := l.data.Close(); err != nil {
@@ -803,6 +1593,11 @@ func (l *ListTreeWriter) Encoding() *proto.ColumnEncoding {
 	}
 }
 
+func (l *ListTreeWriter) RecordPositions() {
+	l.BaseTreeWriter.RecordPositions()
+	l.child.RecordPositions()
+}
+
 type MapTreeWriter struct {
 	BaseTreeWriter
 	lengths IntegerWriter
@@ -814,13 +1609,13 @@ type MapTreeWriter struct {
 func NewMapTreeWriter(category Category, codec CompressionCodec, keyWriter, valueWriter TreeWriter) (*MapTreeWriter, error) {
 	base := NewBaseTreeWriter(category, codec)
 	data := base.AddStream(proto.Stream_LENGTH.Enum())
-	base.AddPositionRecorder(data)
 	// TODO: Inherit column encoding kind from orc.Writer ORC file version.
 	columnEncoding := proto.ColumnEncoding_DIRECT_V2
 	iwriter, err := createIntegerWriter(columnEncoding, data.buffer, false)
 	if err != nil {
 		return nil, err
 	}
+	base.AddPositionRecorder(iwriter)
 	l := &MapTreeWriter{
 		BaseTreeWriter: base,
 		lengths:        iwriter,
@@ -865,6 +1660,51 @@ func (m *MapTreeWriter) Write(value interface{}) error {
 	}
 }
 
+// writeVector writes the values of a MapVector from index start up to end.
+// The entries of null maps are not written.
+func (m *MapTreeWriter) writeVector(v ColumnVector, start, end int) error {
+	vec, ok := v.(*MapVector)
+	if !ok {
+		return fmt.Errorf("cannot write %T to map column type", v)
+	}
+	if vec.Offsets[end] > vec.Keys.Len() || vec.Offsets[end] > vec.Values.Len() {
+		return fmt.Errorf("map offset %v exceeds the length of the key or value vector", vec.Offsets[end])
+	}
+	// Write the entries of each run of maps that are not null.
+	from := vec.Offsets[start]
+	for i := start; i < end; i++ {
+		if vec.IsNull(i) {
+			if err := m.writeEntries(vec, from, vec.Offsets[i]); err != nil {
+				return err
+			}
+			from = vec.Offsets[i+1]
+			if err := m.BaseTreeWriter.writeNull(); err != nil {
+				return err
+			}
+			continue
+		}
+		n := vec.Offsets[i+1] - vec.Offsets[i]
+		if n < 0 {
+			return fmt.Errorf("invalid map offsets: %v, %v", vec.Offsets[i], vec.Offsets[i+1])
+		}
+		if err := m.BaseTreeWriter.writeValue(); err != nil {
+			return err
+		}
+		if err := m.lengths.WriteInt(int64(n)); err != nil {
+			return err
+		}
+	}
+	return m.writeEntries(vec, from, vec.Offsets[end])
+}
+
+// writeEntries writes the keys and values of vec from index start up to end.
+func (m *MapTreeWriter) writeEntries(vec *MapVector, start, end int) error {
+	if err := writeVector(m.keys, vec.Keys, start, end); err != nil {
+		return err
+	}
+	return writeVector(m.values, vec.Values, start, end)
+}
+
 func (m *MapTreeWriter) Flush() error {
 	if err := m.lengths.Flush(); err != nil {
 		return err
@@ -882,13 +1722,19 @@ func (m *MapTreeWriter) Flush() error {
 }
 
 func (m *MapTreeWriter) Close() error {
-	if err := m.lengths.Close(); err != nil {
+	if err := m.closeColumn(); err != nil {
 		return err
 	}
 	if err := m.keys.Close(); err != nil {
 		return err
 	}
-	if err := m.values.Close(); err != nil {
+	return m.values.Close()
+}
+
+// closeColumn closes the streams of the MapTreeWriter without closing its key
+// and value TreeWriters.
+func (m *MapTreeWriter) closeColumn() error {
+	if err := m.lengths.Close(); err != nil {
 		return err
 	}
 	if err := m.data.Close(); err != nil {
@@ -903,6 +1749,12 @@ func (m *MapTreeWriter) Encoding() *proto.ColumnEncoding {
 	}
 }
 
+func (m *MapTreeWriter) RecordPositions() {
+	m.BaseTreeWriter.RecordPositions()
+	m.keys.RecordPositions()
+	m.values.RecordPositions()
+}
+
 // TimestampWriter is an interface implemented by all Timestamp type writers.
 type TimestampWriter interface {
 	WriteTimestamp(value time.Time) error
@@ -917,25 +1769,30 @@ type TimestampTreeWriter struct {
 	secondary          *BufferedWriter
 	dataIntWriter      IntegerWriter
 	secondaryIntWriter IntegerWriter
+	// location is the writer timezone and base is the seconds since the epoch
+	// of 1 January 2015 in it, which timestamps are written relative to.
+	// Timestamps are written in UTC if location is nil.
+	location *time.Location
+	base     int64
 }
 
 // NewTimestampTreeWriter returns a new TimestampTreeWriter.
 func NewTimestampTreeWriter(category Category, codec CompressionCodec) (*TimestampTreeWriter, error) {
 	base := NewBaseTreeWriter(category, codec)
 	data := base.AddStream(proto.Stream_DATA.Enum())
-	base.AddPositionRecorder(data)
 	secondary := base.AddStream(proto.Stream_SECONDARY.Enum())
-	base.AddPositionRecorder(secondary)
 
 	dataIntWriter, err := createIntegerWriter(proto.ColumnEncoding_DIRECT_V2, data.buffer, true)
 	if err != nil {
 		return nil, err
 	}
+	base.AddPositionRecorder(dataIntWriter)
 
 	secondaryIntWriter, err := createIntegerWriter(proto.ColumnEncoding_DIRECT_V2, secondary.buffer, false)
 	if err != nil {
 		return nil, err
 	}
+	base.AddPositionRecorder(secondaryIntWriter)
 
 	return &TimestampTreeWriter{
 		BaseTreeWriter:     base,
@@ -943,12 +1800,13 @@ func NewTimestampTreeWriter(category Category, codec CompressionCodec) (*Timesta
 		secondary:          secondary.buffer,
 		dataIntWriter:      dataIntWriter,
 		secondaryIntWriter: secondaryIntWriter,
+		base:               TimestampBaseSeconds,
 	}, nil
 }
 
 // WriteTimestamp writes an Timestamp value returning an error if one occurs.
 func (w *TimestampTreeWriter) WriteTimestamp(value time.Time) error {
-	secs := value.Unix() - TimestampBaseSeconds
+	secs := value.Unix() - w.base
 	if err := w.dataIntWriter.WriteInt(secs); err != nil {
 		return err
 	}
@@ -975,8 +1833,7 @@ func (w *TimestampTreeWriter) Write(value interface{}) error {
 		return nil
 	case time.Time:
 		// First write the value to the present column.
-		//TODO: CHECK IF THIS IS THE RIGHT VALUE
-		if err := w.BaseTreeWriter.Write(t); err != nil {
+		if err := w.BaseTreeWriter.Write(w.in(t)); err != nil {
 			return err
 		}
 		return w.WriteTimestamp(t)
@@ -985,6 +1842,37 @@ func (w *TimestampTreeWriter) Write(value interface{}) error {
 	}
 }
 
+// writeVector writes the values of a TimestampVector from index start up to end.
+func (w *TimestampTreeWriter) writeVector(v ColumnVector, start, end int) error {
+	vec, ok := v.(*TimestampVector)
+	if !ok {
+		return fmt.Errorf("cannot write %T to Timestamp column type", v)
+	}
+	return w.BaseTreeWriter.writeRuns(vec.IsNull, start, end, func(from, to int) error {
+		secs := make([]int64, to-from)
+		nanos := make([]int64, to-from)
+		for i, value := range vec.Values[from:to] {
+			if err := w.BaseTreeWriter.Write(w.in(value)); err != nil {
+				return err
+			}
+			secs[i] = value.Unix() - w.base
+			nanos[i] = formatNanos(int64(value.Nanosecond()))
+		}
+		if err := w.dataIntWriter.WriteInts(secs); err != nil {
+			return err
+		}
+		return w.secondaryIntWriter.WriteInts(nanos)
+	})
+}
+
+// in returns the time in the writer timezone.
+func (w *TimestampTreeWriter) in(t time.Time) time.Time {
+	if w.location == nil {
+		return t.UTC()
+	}
+	return t.In(w.location)
+}
+
 // Close closes the underlying writers returning an error if one occurs.
 func (w *TimestampTreeWriter) Close() error {
 	if err := w.Flush(); err != nil {
@@ -1043,11 +1931,12 @@ type UnionTreeWriter struct {
 func NewUnionTreeWriter(category Category, codec CompressionCodec, children []TreeWriter) (*UnionTreeWriter, error) {
 	base := NewBaseTreeWriter(category, codec)
 	data := base.AddStream(proto.Stream_DATA.Enum())
-	base.AddPositionRecorder(data)
+	dataWriter := NewRunLengthByteWriter(data.buffer)
+	base.AddPositionRecorder(dataWriter)
 	return &UnionTreeWriter{
 		BaseTreeWriter: base,
 		data:           data.buffer,
-		dataWriter:     NewRunLengthByteWriter(data.buffer),
+		dataWriter:     dataWriter,
 		children:       children,
 	}, nil
 }
@@ -1075,16 +1964,43 @@ func (s *UnionTreeWriter) Write(value interface{}) error {
 	return fmt.Errorf("cannot write %T to unionvalue column type", value)
 }
 
+// writeVector writes the values of a UnionVector from index start up to end.
+func (s *UnionTreeWriter) writeVector(v ColumnVector, start, end int) error {
+	vec, ok := v.(*UnionVector)
+	if !ok {
+		return fmt.Errorf("cannot write %T to unionvalue column type", v)
+	}
+	if len(vec.Children) != len(s.children) {
+		return fmt.Errorf("wrong number of union children, expected: %v, got: %v", len(s.children), len(vec.Children))
+	}
+	for i := start; i < end; i++ {
+		if vec.IsNull(i) {
+			if err := s.BaseTreeWriter.writeNull(); err != nil {
+				return err
+			}
+			continue
+		}
+		tag := vec.Tags[i]
+		if tag >= len(s.children) || tag < 0 {
+			return fmt.Errorf("invalid tag: %v", tag)
+		}
+		if err := s.BaseTreeWriter.writeValue(); err != nil {
+			return err
+		}
+		if err := s.dataWriter.WriteByte(uint8(tag)); err != nil {
+			return err
+		}
+		if err := writeVector(s.children[tag], vec.Children[tag], i, i+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Close closes the UnionTreeWriter and its child TreeWriters returning an
 // error if one occurs.
 func (s *UnionTreeWriter) Close() error {
-	if err := s.BaseTreeWriter.Close(); err != nil {
-		return err
-	}
-	if err := s.dataWriter.Close(); err != nil {
-		return err
-	}
-	if err := s.data.Close(); err != nil {
+	if err := s.closeColumn(); err != nil {
 		return err
 	}
 	for i := range s.children {
@@ -1096,6 +2012,18 @@ func (s *UnionTreeWriter) Close() error {
 	return nil
 }
 
+// closeColumn closes the streams of the UnionTreeWriter without closing its
+// child TreeWriters.
+func (s *UnionTreeWriter) closeColumn() error {
+	if err := s.BaseTreeWriter.Close(); err != nil {
+		return err
+	}
+	if err := s.dataWriter.Close(); err != nil {
+		return err
+	}
+	return s.data.Close()
+}
+
 // Flush flushes the UnionTreeWriter and its child TreeWriters returning an
 // error if one occurs.
 func (s *UnionTreeWriter) Flush() error {
@@ -1143,11 +2071,11 @@ type DateTreeWriter struct {
 func NewDateTreeWriter(category Category, codec CompressionCodec) (*DateTreeWriter, error) {
 	base := NewBaseTreeWriter(category, codec)
 	data := base.AddStream(proto.Stream_DATA.Enum())
-	base.AddPositionRecorder(data)
 	dataIntWriter, err := createIntegerWriter(proto.ColumnEncoding_DIRECT_V2, data.buffer, true)
 	if err != nil {
 		return nil, err
 	}
+	base.AddPositionRecorder(dataIntWriter)
 	return &DateTreeWriter{
 		BaseTreeWriter: base,
 		data:           data.buffer,
@@ -1165,7 +2093,7 @@ func (w *DateTreeWriter) Encoding() *proto.ColumnEncoding {
 
 // WriteDate writes an Date value returning an error if one occurs.
 func (w *DateTreeWriter) WriteDate(date time.Time) error {
-	daySinceEpoch := date.Truncate(24*time.Hour).Unix() / 86400
+	daySinceEpoch := daysSinceEpoch(date)
 	if err := w.dataIntWriter.WriteInt(daySinceEpoch); err != nil {
 		return err
 	}
@@ -1192,6 +2120,22 @@ func (w *DateTreeWriter) Write(value interface{}) error {
 	}
 }
 
+// writeVector writes the values of a LongVector, holding the number of days
+// since the Unix epoch, from index start up to end.
+func (w *DateTreeWriter) writeVector(v ColumnVector, start, end int) error {
+	vec, ok := v.(*LongVector)
+	if !ok {
+		return fmt.Errorf("cannot write %T to Date column type", v)
+	}
+	return w.BaseTreeWriter.writeRuns(vec.IsNull, start, end, func(from, to int) error {
+		values := vec.Values[from:to]
+		if err := w.BaseTreeWriter.writeInts(values); err != nil {
+			return err
+		}
+		return w.dataIntWriter.WriteInts(values)
+	})
+}
+
 // Close closes the underlying writers returning an error if one occurs.
 func (w *DateTreeWriter) Close() error {
 	if err := w.Flush(); err != nil {
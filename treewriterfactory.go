@@ -2,9 +2,10 @@ package orc
 
 import (
 	"fmt"
+	"time"
 )
 
-func createTreeWriter(codec CompressionCodec, schema *TypeDescription, writers writerMap) (TreeWriter, error) {
+func createTreeWriter(codec CompressionCodec, schema *TypeDescription, writers writerMap, truncateStrings bool, location *time.Location) (TreeWriter, error) {
 
 	id := schema.getID()
 	var treeWriter TreeWriter
@@ -30,7 +31,7 @@ func createTreeWriter(codec CompressionCodec, schema *TypeDescription, writers w
 		// Create a TreeWriter for each child of the struct column.
 		var children []TreeWriter
 		for _, child := range schema.children {
-			childWriter, err := createTreeWriter(codec, child, writers)
+			childWriter, err := createTreeWriter(codec, child, writers, truncateStrings, location)
 			if err != nil {
 				return nil, err
 			}
@@ -40,21 +41,41 @@ func createTreeWriter(codec CompressionCodec, schema *TypeDescription, writers w
 		if err != nil {
 			return nil, err
 		}
+	case CategoryByte:
+		treeWriter, err = NewByteTreeWriter(category, codec)
+		if err != nil {
+			return nil, err
+		}
 	case CategoryShort, CategoryInt, CategoryLong:
 		treeWriter, err = NewIntegerTreeWriter(category, codec)
 		if err != nil {
 			return nil, err
 		}
-	case CategoryVarchar, CategoryString:
+	case CategoryString:
 		treeWriter, err = NewStringTreeWriter(category, codec)
 		if err != nil {
 			return nil, err
 		}
+	case CategoryVarchar, CategoryChar:
+		treeWriter, err = NewCharTreeWriter(category, codec, schema.maxLength, truncateStrings)
+		if err != nil {
+			return nil, err
+		}
+	case CategoryBinary:
+		treeWriter, err = NewBinaryTreeWriter(category, codec)
+		if err != nil {
+			return nil, err
+		}
+	case CategoryDecimal:
+		treeWriter, err = NewDecimalTreeWriter(category, codec, schema.precision, schema.scale)
+		if err != nil {
+			return nil, err
+		}
 	case CategoryList:
 		if len(schema.children) != 1 {
 			return nil, fmt.Errorf("unexpected number of children for list column, expected 1 got %v", len(schema.children))
 		}
-		child, err := createTreeWriter(codec, schema.children[0], writers)
+		child, err := createTreeWriter(codec, schema.children[0], writers, truncateStrings, location)
 		if err != nil {
 			return nil, err
 		}
@@ -66,11 +87,11 @@ func createTreeWriter(codec CompressionCodec, schema *TypeDescription, writers w
 		if len(schema.children) != 2 {
 			return nil, fmt.Errorf("unexpected number of children for map column, expected 2 got %v", len(schema.children))
 		}
-		keyWriter, err := createTreeWriter(codec, schema.children[0], writers)
+		keyWriter, err := createTreeWriter(codec, schema.children[0], writers, truncateStrings, location)
 		if err != nil {
 			return nil, err
 		}
-		valueWriter, err := createTreeWriter(codec, schema.children[1], writers)
+		valueWriter, err := createTreeWriter(codec, schema.children[1], writers, truncateStrings, location)
 		if err != nil {
 			return nil, err
 		}
@@ -78,16 +99,22 @@ func createTreeWriter(codec CompressionCodec, schema *TypeDescription, writers w
 		if err != nil {
 			return nil, err
 		}
-	case CategoryTimestamp:
-		treeWriter, err = NewTimestampTreeWriter(category, codec)
+	case CategoryTimestamp, CategoryTimestampInstant:
+		timestampWriter, err := NewTimestampTreeWriter(category, codec)
 		if err != nil {
 			return nil, err
 		}
+		// Timestamps with local time zone are instants written in UTC.
+		if category == CategoryTimestamp {
+			timestampWriter.location = location
+			timestampWriter.base = timestampBase(location)
+		}
+		treeWriter = timestampWriter
 	case CategoryUnion:
 		// Create a TreeWriter for each child of the unionvalue column.
 		var children []TreeWriter
 		for _, child := range schema.children {
-			childWriter, err := createTreeWriter(codec, child, writers)
+			childWriter, err := createTreeWriter(codec, child, writers, truncateStrings, location)
 			if err != nil {
 				return nil, err
 			}
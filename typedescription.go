@@ -21,25 +21,26 @@ func (c Category) String() string {
 }
 
 var (
-	CategoryBoolean   = Category{"boolean", true, proto.Type_BOOLEAN.Enum()}
-	CategoryByte      = Category{"tinyint", true, proto.Type_BYTE.Enum()}
-	CategoryShort     = Category{"smallint", true, proto.Type_SHORT.Enum()}
-	CategoryInt       = Category{"int", true, proto.Type_INT.Enum()}
-	CategoryLong      = Category{"bigint", true, proto.Type_LONG.Enum()}
-	CategoryFloat     = Category{"float", true, proto.Type_FLOAT.Enum()}
-	CategoryDouble    = Category{"double", true, proto.Type_DOUBLE.Enum()}
-	CategoryString    = Category{"string", true, proto.Type_STRING.Enum()}
-	CategoryDate      = Category{"date", true, proto.Type_DATE.Enum()}
-	CategoryTimestamp = Category{"timestamp", true, proto.Type_TIMESTAMP.Enum()}
-	CategoryBinary    = Category{"binary", true, proto.Type_BINARY.Enum()}
-	CategoryDecimal   = Category{"decimal", true, proto.Type_DECIMAL.Enum()}
-	CategoryVarchar   = Category{"varchar", true, proto.Type_VARCHAR.Enum()}
-	CategoryChar      = Category{"char", true, proto.Type_CHAR.Enum()}
-	CategoryList      = Category{"array", false, proto.Type_LIST.Enum()}
-	CategoryMap       = Category{"map", false, proto.Type_MAP.Enum()}
-	CategoryStruct    = Category{"struct", false, proto.Type_STRUCT.Enum()}
-	CategoryUnion     = Category{"uniontype", false, proto.Type_UNION.Enum()}
-	Categories        = []Category{
+	CategoryBoolean          = Category{"boolean", true, proto.Type_BOOLEAN.Enum()}
+	CategoryByte             = Category{"tinyint", true, proto.Type_BYTE.Enum()}
+	CategoryShort            = Category{"smallint", true, proto.Type_SHORT.Enum()}
+	CategoryInt              = Category{"int", true, proto.Type_INT.Enum()}
+	CategoryLong             = Category{"bigint", true, proto.Type_LONG.Enum()}
+	CategoryFloat            = Category{"float", true, proto.Type_FLOAT.Enum()}
+	CategoryDouble           = Category{"double", true, proto.Type_DOUBLE.Enum()}
+	CategoryString           = Category{"string", true, proto.Type_STRING.Enum()}
+	CategoryDate             = Category{"date", true, proto.Type_DATE.Enum()}
+	CategoryTimestamp        = Category{"timestamp", true, proto.Type_TIMESTAMP.Enum()}
+	CategoryTimestampInstant = Category{"timestamp with local time zone", true, proto.Type_TIMESTAMP_INSTANT.Enum()}
+	CategoryBinary           = Category{"binary", true, proto.Type_BINARY.Enum()}
+	CategoryDecimal          = Category{"decimal", true, proto.Type_DECIMAL.Enum()}
+	CategoryVarchar          = Category{"varchar", true, proto.Type_VARCHAR.Enum()}
+	CategoryChar             = Category{"char", true, proto.Type_CHAR.Enum()}
+	CategoryList             = Category{"array", false, proto.Type_LIST.Enum()}
+	CategoryMap              = Category{"map", false, proto.Type_MAP.Enum()}
+	CategoryStruct           = Category{"struct", false, proto.Type_STRUCT.Enum()}
+	CategoryUnion            = Category{"uniontype", false, proto.Type_UNION.Enum()}
+	Categories               = []Category{
 		CategoryBoolean,
 		CategoryByte,
 		CategoryShort,
@@ -54,6 +55,7 @@ var (
 		CategoryDecimal,
 		CategoryVarchar,
 		CategoryChar,
+		CategoryTimestampInstant,
 		CategoryList,
 		CategoryMap,
 		CategoryStruct,
@@ -100,7 +102,9 @@ func (s *stringPosition) parseCategory() (Category, error) {
 	if s.position != start {
 		word := strings.ToLower(string([]rune(s.value)[start:s.position]))
 		for _, cat := range Categories {
-			if cat.name == word {
+			// Whitespace is removed from the value, so is also removed from
+			// names such as "timestamp with local time zone".
+			if strings.Replace(cat.name, " ", "", -1) == word {
 				return cat, nil
 			}
 		}
@@ -233,7 +237,8 @@ func (s *stringPosition) parseType() (*TypeDescription, error) {
 		CategoryLong.name,
 		CategoryShort.name,
 		CategoryString.name,
-		CategoryTimestamp.name:
+		CategoryTimestamp.name,
+		CategoryTimestampInstant.name:
 	case CategoryChar.name,
 		CategoryVarchar.name:
 		err = s.requireChar('(')
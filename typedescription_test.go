@@ -59,6 +59,20 @@ func TestTypeDescriptionParse(t *testing.T) {
 		t.Errorf("Test failed, expected %s got %s", expected, td.ToJSON())
 	}
 
+	description = NewStringPosition(`struct<f1:timestamp,f2:timestamp with local time zone>`)
+
+	td, err = description.parseType()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected = `struct<f1:timestamp,f2:timestamp with local time zone>`
+	if td.String() != expected {
+		t.Errorf("Test failed, expected %s got %s", expected, td.String())
+	}
+	if td.children[1].category != CategoryTimestampInstant {
+		t.Errorf("Test failed, expected %v got %v", CategoryTimestampInstant, td.children[1].category)
+	}
 }
 
 func TestTypeDescriptionPrint(t *testing.T) {
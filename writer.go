@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"reflect"
+	"time"
 
 	gproto "github.com/golang/protobuf/proto"
 	"github.com/scritchley/orc/proto"
@@ -51,6 +53,11 @@ type Writer struct {
 	indexOffset          uint64
 	chunkOffset          uint64
 	compressionCodec     CompressionCodec
+	truncateStrings      bool
+	bloomFilterColumns   []string
+	bloomFilterFpp       float64
+	flushConcurrency     int
+	location             *time.Location
 }
 
 func ptrInt64(i int64) *int64 {
@@ -73,10 +80,15 @@ func SetCompression(codec CompressionCodec) WriterConfigFunc {
 		case nil:
 		case CompressionNone:
 		case CompressionSnappy:
-			return fmt.Errorf("Unknown compression codec type %T", codec)
-			// w.postScript.Compression = proto.CompressionKind_SNAPPY.Enum()
+			w.postScript.Compression = proto.CompressionKind_SNAPPY.Enum()
+		case CompressionLZ4:
+			w.postScript.Compression = proto.CompressionKind_LZ4.Enum()
+		case CompressionZstd:
+			w.postScript.Compression = proto.CompressionKind_ZSTD.Enum()
 		case CompressionZlib:
 			w.postScript.Compression = proto.CompressionKind_ZLIB.Enum()
+		case CompressionLZO:
+			return errLZOWriteUnsupported
 		default:
 			return fmt.Errorf("Unknown compression codec type %T", codec)
 		}
@@ -93,6 +105,66 @@ func SetStripeTargetSize(stripeTargetSize int64) WriterConfigFunc {
 	}
 }
 
+// SetTruncateStrings determines how values longer than the maximum length of a
+// char or varchar column are handled. If truncate is true, which is the default,
+// values are truncated to the maximum length as Hive does. Otherwise an error is
+// returned when writing the value.
+func SetTruncateStrings(truncate bool) WriterConfigFunc {
+	return func(w *Writer) error {
+		w.truncateStrings = truncate
+		return nil
+	}
+}
+
+// SetBloomFilterColumns enables writing bloom filters for the provided columns
+// with a false positive probability of fpp. A bloom filter is written for each
+// row index stride of each column, which must be a primitive type.
+func SetBloomFilterColumns(columns []string, fpp float64) WriterConfigFunc {
+	return func(w *Writer) error {
+		if fpp <= 0 || fpp >= 1 {
+			return fmt.Errorf("bloom filter false positive probability must be between 0 and 1, got %v", fpp)
+		}
+		w.bloomFilterColumns = columns
+		w.bloomFilterFpp = fpp
+		return nil
+	}
+}
+
+// SetWriterTimezone sets the timezone of the writer, which is recorded in each
+// stripe footer. Timestamps are written relative to 1 January 2015 in the
+// timezone, so must be a location of the tz database such as
+// "America/Los_Angeles" that readers can load. Timestamps are written in GMT by
+// default.
+func SetWriterTimezone(loc *time.Location) WriterConfigFunc {
+	return func(w *Writer) error {
+		if loc == nil {
+			return fmt.Errorf("invalid writer timezone: nil")
+		}
+		// Use the location that readers load, as the offsets of a location
+		// such as one returned by time.FixedZone may differ.
+		loaded, err := loadTimezone(loc.String())
+		if err != nil {
+			return fmt.Errorf("invalid writer timezone: %v", err)
+		}
+		w.location = loaded
+		return nil
+	}
+}
+
+// SetFlushConcurrency sets the number of goroutines used to close the column
+// writers, encoding and compressing their remaining data, when a stripe is
+// written. Streams are written in the same order regardless of concurrency.
+// Columns are closed on the calling goroutine if n is 0 or 1, the default.
+func SetFlushConcurrency(n int) WriterConfigFunc {
+	return func(w *Writer) error {
+		if n < 0 {
+			return fmt.Errorf("invalid flush concurrency %v", n)
+		}
+		w.flushConcurrency = n
+		return nil
+	}
+}
+
 func AddUserMetadata(name string, value []byte) WriterConfigFunc {
 	return func(w *Writer) error {
 		w.footer.Metadata = append(w.footer.Metadata, &proto.UserMetadataItem{
@@ -131,6 +203,7 @@ func NewWriter(w io.Writer, fns ...WriterConfigFunc) (*Writer, error) {
 			StripeStats: []*proto.StripeStatistics{},
 		},
 		compressionCodec: CompressionNone{},
+		truncateStrings:  true,
 	}
 
 	// Apply any WriterConfigFuncs to the new writer.
@@ -159,7 +232,7 @@ func (w *Writer) Write(values ...interface{}) error {
 	if err != nil {
 		return err
 	}
-	if w.totalRows%uint64(w.footer.GetRowIndexStride()) == 0 {
+	if w.stripeRows%uint64(w.footer.GetRowIndexStride()) == 0 {
 		// Records and resets indexes for each writer.
 		w.recordPositions()
 
@@ -173,6 +246,80 @@ func (w *Writer) Write(values ...interface{}) error {
 	return nil
 }
 
+// WriteStruct writes the exported fields of the struct, or pointer to a struct,
+// v as a row. Each field of the schema is written from the struct field with
+// an orc tag matching its name, or otherwise the field whose name matches it
+// ignoring case, as named by SchemaFor, so the order of the fields does not
+// matter. Nested structs are matched in the same way. Nil pointers, slices and
+// maps and Null types in database/sql holding no value are written as null. An
+// error is returned if a field of the schema has no matching struct field or
+// its value cannot be converted to the type of the column, in which case no
+// values of the row are written.
+func (w *Writer) WriteStruct(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("expected a struct or non-nil pointer to a struct, got %T", v)
+	}
+	if w.schema.category != CategoryStruct {
+		return fmt.Errorf("cannot write struct to %s schema", w.schema.getCategory())
+	}
+	values, err := structValue(w.schema, rv)
+	if err != nil {
+		return err
+	}
+	return w.Write(values.([]interface{})...)
+}
+
+// WriteBatch writes the first b.Len rows of b, which must hold a ColumnVector
+// for each field of the schema in order, such as a Batch filled by NextBatch
+// from a Cursor that selects every column. Values are written directly from
+// the vectors rather than being converted to an interface{} as Write does. Maps
+// that are not null are written with their entries, even if they have none.
+func (w *Writer) WriteBatch(b *Batch) error {
+	root, ok := w.treeWriter.(*StructTreeWriter)
+	if !ok {
+		return fmt.Errorf("cannot write batch to %s schema", w.schema.getCategory())
+	}
+	if len(b.Columns) != len(root.children) {
+		return fmt.Errorf("wrong number of columns, expected: %v, got: %v", len(root.children), len(b.Columns))
+	}
+	for i, column := range b.Columns {
+		if column.Len() < b.Len {
+			return fmt.Errorf("column %v has %v values, expected: %v", i, column.Len(), b.Len)
+		}
+	}
+	rows := &StructVector{Fields: b.Columns}
+	stride := uint64(w.footer.GetRowIndexStride())
+	for start := 0; start < b.Len; {
+		// Write the rows up to the end of the current row group, so that
+		// positions are recorded after the same rows as Write.
+		end := start + int(stride-w.stripeRows%stride)
+		if end > b.Len {
+			end = b.Len
+		}
+		if err := root.writeVector(rows, start, end); err != nil {
+			return err
+		}
+		w.stripeRows += uint64(end - start)
+		w.totalRows += uint64(end - start)
+		start = end
+		if w.stripeRows%stride == 0 {
+			// Records and resets indexes for each writer.
+			w.recordPositions()
+
+			if w.treeWriters.size() >= w.stripeTargetSize || int64(w.stripeRows) >= w.stripeTargetRowCount {
+				if err := w.writeStripe(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // Flush the current stripe to the underlying Writer
 func (w *Writer) Flush() error {
 	return w.writeStripe()
@@ -199,26 +346,37 @@ func (w *Writer) initOrc() error {
 func (w *Writer) initWriters() error {
 	var err error
 	w.treeWriters = make(writerMap)
-	w.treeWriter, err = createTreeWriter(w.compressionCodec, w.schema, w.treeWriters)
+	w.treeWriter, err = createTreeWriter(w.compressionCodec, w.schema, w.treeWriters, w.truncateStrings, w.location)
 	if err != nil {
 		return err
 	}
+	for _, column := range w.bloomFilterColumns {
+		td, err := w.schema.GetField(column)
+		if err != nil {
+			return err
+		}
+		if !td.category.isPrimitive {
+			return fmt.Errorf("cannot write bloom filter for %s column %s", td.category, column)
+		}
+		w.treeWriters[td.getID()].EnableBloomFilter(int64(w.footer.GetRowIndexStride()), w.bloomFilterFpp)
+	}
 	return nil
 }
 
+// closeWriters records the final row group of the stripe and closes the
+// TreeWriters, which flush and compress their remaining data. The final row
+// group starts at positions recorded before any of its data is flushed, so
+// each column can be closed by a separate goroutine.
 func (w *Writer) closeWriters() error {
-	if err := w.flushWriters(); err != nil {
-		return err
+	// Record the final row group unless the stripe ends on a row index stride,
+	// in which case it has already been recorded.
+	if w.stripeRows%uint64(w.footer.GetRowIndexStride()) != 0 {
+		w.recordPositions()
 	}
-	return w.treeWriter.Close()
-}
-
-func (w *Writer) flushWriters() error {
-	if err := w.treeWriter.Flush(); err != nil {
-		return err
+	if w.flushConcurrency > 1 {
+		return w.treeWriters.closeColumns(w.flushConcurrency)
 	}
-	w.recordPositions()
-	return nil
+	return w.treeWriter.Close()
 }
 
 func (w *Writer) recordPositions() {
@@ -344,42 +502,24 @@ func (w *Writer) writeStripe() error {
 		if rowIndex == nil {
 			return nil
 		}
-		byt, err := gproto.Marshal(rowIndex)
+		stream, err := w.writeIndex(id, proto.Stream_ROW_INDEX, rowIndex, buf)
 		if err != nil {
 			return err
 		}
-		encoder := w.compressionCodec.Encoder(buf)
+		stripeIndexLength += stream.GetLength()
+		streams = append(streams, stream)
 
-		n, err := encoder.Write(byt)
-		if err != nil {
-			return err
-		}
-		if n != len(byt) {
-			return fmt.Errorf("Expected to write %d bytes, wrote %d", len(byt), n)
-		}
-
-		err = encoder.Close()
-		if err != nil {
-			return err
+		// Write the bloom filters for the column, if enabled.
+		bloomFilterIndex := t.BloomFilterIndex()
+		if bloomFilterIndex == nil {
+			return nil
 		}
-
-		l := buf.Len()
-		nn, err := io.Copy(w.w, buf)
+		stream, err = w.writeIndex(id, proto.Stream_BLOOM_FILTER_UTF8, bloomFilterIndex, buf)
 		if err != nil {
 			return err
 		}
-
-		if int(nn) != l {
-			return fmt.Errorf("Expected to write %d bytes, wrote %d", l, nn)
-		}
-
-		stripeIndexLength += uint64(l)
-		streamInfo := &proto.Stream{
-			Column: ptrUint32(uint32(id)),
-			Kind:   proto.Stream_ROW_INDEX.Enum(),
-			Length: ptrUint64(uint64(l)),
-		}
-		streams = append(streams, streamInfo)
+		stripeIndexLength += stream.GetLength()
+		streams = append(streams, stream)
 		return nil
 	})
 	if err != nil {
@@ -415,11 +555,23 @@ func (w *Writer) writeStripe() error {
 		return err
 	}
 
+	// Set the bloom filter encoding for columns with bloom filters.
+	encodings := w.treeWriters.encodings()
+	for id, t := range w.treeWriters {
+		if t.BloomFilterIndex() != nil {
+			encodings[id].BloomEncoding = ptrUint32(bloomFilterEncodingUTF8)
+		}
+	}
+
 	// Create a stripe footer and write it to the underlying writer.
+	writerTimezone := DefaultStripeWriterTimezone
+	if w.location != nil {
+		writerTimezone = w.location.String()
+	}
 	stripeFooter := &proto.StripeFooter{
 		Streams:        streams,
-		Columns:        w.treeWriters.encodings(),
-		WriterTimezone: &DefaultStripeWriterTimezone,
+		Columns:        encodings,
+		WriterTimezone: &writerTimezone,
 	}
 
 	byt, err := gproto.Marshal(stripeFooter)
@@ -469,9 +621,14 @@ func (w *Writer) writeStripe() error {
 	// Update the stripe offset for the next stripe by combining the index, data and footer lengths.
 	w.stripeOffset += stripeIndexLength + stripeDataLength + footerLength
 
-	// Add stripe statistics to metadata
+	// Add a copy of the stripe statistics to metadata, as the first stripe's
+	// statistics are updated when later stripes are merged into the totals.
+	colStats := stripeStatistics.statistics()
+	for i := range colStats {
+		colStats[i] = gproto.Clone(colStats[i]).(*proto.ColumnStatistics)
+	}
 	w.metadata.StripeStats = append(w.metadata.StripeStats, &proto.StripeStatistics{
-		ColStats: stripeStatistics.statistics(),
+		ColStats: colStats,
 	})
 
 	// Merge the stripe statistics with the total statistics.
@@ -480,6 +637,45 @@ func (w *Writer) writeStripe() error {
 	return w.initWriters()
 }
 
+// writeIndex compresses and writes the index message for the column with the
+// provided id, returning the Stream describing it.
+func (w *Writer) writeIndex(id int, kind proto.Stream_Kind, index gproto.Message, buf *bytes.Buffer) (*proto.Stream, error) {
+	byt, err := gproto.Marshal(index)
+	if err != nil {
+		return nil, err
+	}
+	encoder := w.compressionCodec.Encoder(buf)
+
+	n, err := encoder.Write(byt)
+	if err != nil {
+		return nil, err
+	}
+	if n != len(byt) {
+		return nil, fmt.Errorf("Expected to write %d bytes, wrote %d", len(byt), n)
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	l := buf.Len()
+	nn, err := io.Copy(w.w, buf)
+	if err != nil {
+		return nil, err
+	}
+
+	if int(nn) != l {
+		return nil, fmt.Errorf("Expected to write %d bytes, wrote %d", l, nn)
+	}
+
+	return &proto.Stream{
+		Column: ptrUint32(uint32(id)),
+		Kind:   kind.Enum(),
+		Length: ptrUint64(uint64(l)),
+	}, nil
+}
+
 func (w *Writer) Close() error {
 	if err := w.writeStripe(); err != nil {
 		return err
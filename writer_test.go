@@ -3,14 +3,20 @@ package orc
 import (
 	"bytes"
 	"compress/flate"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math/big"
 	"math/rand"
 	"os"
 	"reflect"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	gproto "github.com/golang/protobuf/proto"
+	"github.com/scritchley/orc/proto"
 )
 
 type bytesSizedReaderAt struct {
@@ -514,3 +520,790 @@ func TestBooleanWriterAlternating(t *testing.T) {
 	}
 
 }
+
+func TestWriterByte(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	schema, err := ParseSchema("struct<byte1:tinyint>")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := NewWriter(buf, SetSchema(schema))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	length := 20000
+	expected := make([]interface{}, length)
+	var sum int64
+	for i := 0; i < length; i++ {
+		var value interface{}
+		switch i % 4 {
+		case 0:
+			value = byte(i)
+			expected[i] = int8(byte(i))
+		case 1:
+			value = int8(-i % 128)
+			expected[i] = int8(-i % 128)
+		case 2:
+			value = i % 100
+			expected[i] = int8(i % 100)
+		default:
+			value = nil
+			expected[i] = nil
+		}
+		if v, ok := expected[i].(int8); ok {
+			sum += int64(v)
+		}
+		err = w.Write(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(&bytesSizedReaderAt{bytes.NewBuffer(buf.Bytes())})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := r.Select("byte1")
+	row := 0
+	for c.Stripes() {
+		for c.Next() {
+			if !reflect.DeepEqual(expected[row], c.Row()[0]) {
+				t.Fatalf("Test failed for row %v, expected %v got %v", row, expected[row], c.Row()[0])
+			}
+			row++
+		}
+	}
+
+	if err := c.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if row != length {
+		t.Errorf("Test failed, expected %v rows got %v", length, row)
+	}
+
+	stats := r.footer.GetStatistics()[1]
+	if got := stats.GetIntStatistics().GetSum(); got != sum {
+		t.Errorf("Test failed, expected sum %v got %v", sum, got)
+	}
+	if !stats.GetHasNull() {
+		t.Errorf("Test failed, expected column statistics to record nulls")
+	}
+
+	bw, err := NewByteTreeWriter(CategoryByte, CompressionNone{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, value := range []int{1000, 200, 128, -129} {
+		if bw.Write(value) == nil {
+			t.Errorf("Test failed, expected an error writing out of range value %v", value)
+		}
+	}
+	for _, value := range []int{127, -128} {
+		if err := bw.Write(value); err != nil {
+			t.Errorf("Test failed, expected no error writing %v got %v", value, err)
+		}
+	}
+}
+
+func TestWriterBinary(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	schema, err := ParseSchema("struct<binary1:binary>")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := NewWriter(buf, SetSchema(schema), SetCompression(CompressionZlib{Level: flate.DefaultCompression}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	length := 10001
+	expected := make([]interface{}, length)
+	var sum int64
+	for i := 0; i < length; i++ {
+		if i%7 == 0 {
+			expected[i] = nil
+		} else {
+			value := make([]byte, rand.Intn(32))
+			rand.Read(value)
+			expected[i] = value
+			sum += int64(len(value))
+		}
+		err = w.Write(expected[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(&bytesSizedReaderAt{bytes.NewBuffer(buf.Bytes())})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := r.Select("binary1")
+	row := 0
+	for c.Stripes() {
+		for c.Next() {
+			if !reflect.DeepEqual(expected[row], c.Row()[0]) {
+				t.Fatalf("Test failed for row %v, expected %v got %v", row, expected[row], c.Row()[0])
+			}
+			row++
+		}
+	}
+
+	if err := c.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if row != length {
+		t.Errorf("Test failed, expected %v rows got %v", length, row)
+	}
+
+	if got := r.footer.GetStatistics()[1].GetBinaryStatistics().GetSum(); got != sum {
+		t.Errorf("Test failed, expected file statistics sum %v got %v", sum, got)
+	}
+	if got := r.Metadata().GetStripeStats()[0].GetColStats()[1].GetBinaryStatistics().GetSum(); got != sum {
+		t.Errorf("Test failed, expected stripe statistics sum %v got %v", sum, got)
+	}
+}
+
+func TestWriterDecimal(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	schema, err := ParseSchema("struct<decimal1:decimal(10,2)>")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := NewWriter(buf, SetSchema(schema))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := []interface{}{
+		NewDecimal(big.NewInt(12345), 2),
+		NewDecimal(big.NewInt(-5), 0),
+		NewDecimal(big.NewInt(1005), 3),
+		big.NewInt(999),
+		*big.NewRat(1, 3),
+		big.NewRat(-7, 4),
+		nil,
+	}
+	expected := []interface{}{
+		NewDecimal(big.NewInt(12345), 2),
+		NewDecimal(big.NewInt(-500), 2),
+		NewDecimal(big.NewInt(101), 2),
+		NewDecimal(big.NewInt(999), 2),
+		NewDecimal(big.NewInt(33), 2),
+		NewDecimal(big.NewInt(-175), 2),
+		nil,
+	}
+
+	for _, v := range values {
+		err = w.Write(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(&bytesSizedReaderAt{bytes.NewBuffer(buf.Bytes())})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := r.Select("decimal1")
+	row := 0
+	for c.Stripes() {
+		for c.Next() {
+			actual := c.Row()[0]
+			if expected[row] == nil {
+				if actual != nil {
+					t.Errorf("Test failed for row %v, expected nil got %v", row, actual)
+				}
+			} else if d, ok := actual.(Decimal); !ok || d.String() != expected[row].(Decimal).String() {
+				t.Errorf("Test failed for row %v, expected %v got %v", row, expected[row], actual)
+			}
+			row++
+		}
+	}
+
+	if err := c.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if row != len(expected) {
+		t.Errorf("Test failed, expected %v rows got %v", len(expected), row)
+	}
+
+	stats := r.footer.GetStatistics()[1].GetDecimalStatistics()
+	if stats.GetMinimum() != "-5.00" {
+		t.Errorf("Test failed, expected minimum -5.00 got %v", stats.GetMinimum())
+	}
+	if stats.GetMaximum() != "123.45" {
+		t.Errorf("Test failed, expected maximum 123.45 got %v", stats.GetMaximum())
+	}
+	if stats.GetSum() != "128.03" {
+		t.Errorf("Test failed, expected sum 128.03 got %v", stats.GetSum())
+	}
+
+	dw, err := NewDecimalTreeWriter(CategoryDecimal, CompressionNone{}, 4, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dw.Write(NewDecimal(big.NewInt(12345), 2)) == nil {
+		t.Errorf("Test failed, expected an error writing a value that exceeds the column precision")
+	}
+}
+
+func TestWriterCharVarchar(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	schema, err := ParseSchema("struct<char1:char(5),varchar1:varchar(3)>")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := NewWriter(buf, SetSchema(schema))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := [][]interface{}{
+		{"ab", "abcdef"},
+		{"abcdefg", "ab"},
+		{"ü", "üüüü"},
+		{nil, nil},
+	}
+	for _, v := range values {
+		err = w.Write(v...)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		trim     bool
+		expected [][]interface{}
+	}{
+		{
+			trim: false,
+			expected: [][]interface{}{
+				{"ab   ", "abc"},
+				{"abcde", "ab"},
+				{"ü    ", "üüü"},
+				{nil, nil},
+			},
+		},
+		{
+			trim: true,
+			expected: [][]interface{}{
+				{"ab", "abc"},
+				{"abcde", "ab"},
+				{"ü", "üüü"},
+				{nil, nil},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		r, err := NewReader(&bytesSizedReaderAt{bytes.NewBuffer(buf.Bytes())}, SetTrimCharPadding(tc.trim))
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := r.Select("char1", "varchar1")
+		row := 0
+		for c.Stripes() {
+			for c.Next() {
+				if !reflect.DeepEqual(tc.expected[row], c.Row()) {
+					t.Errorf("Test failed for row %v, expected %q got %q", row, tc.expected[row], c.Row())
+				}
+				row++
+			}
+		}
+		if err := c.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if row != len(tc.expected) {
+			t.Errorf("Test failed, expected %v rows got %v", len(tc.expected), row)
+		}
+	}
+
+	cw, err := NewCharTreeWriter(CategoryVarchar, CompressionNone{}, 3, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cw.Write("abcd") == nil {
+		t.Errorf("Test failed, expected an error writing a value that exceeds the maximum length")
+	}
+}
+
+func TestWriterWithCompressionCodecs(t *testing.T) {
+	testCases := []struct {
+		codec CompressionCodec
+		kind  proto.CompressionKind
+	}{
+		{CompressionSnappy{}, proto.CompressionKind_SNAPPY},
+		{CompressionLZ4{}, proto.CompressionKind_LZ4},
+		{CompressionZstd{Level: 3}, proto.CompressionKind_ZSTD},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.kind.String(), func(t *testing.T) {
+			buf := &bytes.Buffer{}
+
+			schema, err := ParseSchema("struct<string1:string,int1:int,double1:double>")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			w, err := NewWriter(buf, SetSchema(schema), SetCompression(tc.codec))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			length := 25000
+			var intSum int64
+			for i := 0; i < length; i++ {
+				int1 := rand.Int63n(10000)
+				intSum += int1
+				err = w.Write(fmt.Sprintf("%x", rand.Int63n(1000)), int1, rand.Float64())
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err = w.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r, err := NewReader(&bytesSizedReaderAt{bytes.NewBuffer(buf.Bytes())})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if kind := r.postScript.GetCompression(); kind != tc.kind {
+				t.Errorf("Test failed, expected compression kind %s got %s", tc.kind, kind)
+			}
+
+			var compareIntSum int64
+			c := r.Select("int1")
+			row := 0
+			for c.Stripes() {
+				for c.Next() {
+					compareIntSum += c.Row()[0].(int64)
+					row++
+				}
+			}
+
+			if err := c.Err(); err != nil {
+				t.Fatal(err)
+			}
+
+			if intSum != compareIntSum {
+				t.Errorf("Test failed, expected %v sum got %v", intSum, compareIntSum)
+			}
+
+			if row != length {
+				t.Errorf("Test failed, expected %v rows got %v", length, row)
+			}
+		})
+	}
+}
+
+func TestWriterBloomFilter(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	schema, err := ParseSchema("struct<id:bigint,name:string,value:double>")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := NewWriter(buf, SetSchema(schema), SetCompression(CompressionSnappy{}), SetBloomFilterColumns([]string{"id", "name"}, 0.01))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	length := 25000
+	for i := 0; i < length; i++ {
+		err = w.Write(int64(i), fmt.Sprintf("name-%d", i), rand.Float64())
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(&bytesSizedReaderAt{bytes.NewBuffer(buf.Bytes())})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := r.Select("id", "name", "value")
+	if !c.Stripes() {
+		t.Fatal(c.Err())
+	}
+
+	for column, id := range map[string]int{"id": 1, "name": 2, "value": 3} {
+		encoding, err := c.Stripe.getColumn(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stream := c.Stripe.get(streamName{id, proto.Stream_BLOOM_FILTER_UTF8})
+		if column == "value" {
+			if stream != nil || encoding.BloomEncoding != nil {
+				t.Errorf("Expected no bloom filter for column %s", column)
+			}
+			continue
+		}
+		if encoding.GetBloomEncoding() != bloomFilterEncodingUTF8 {
+			t.Errorf("Expected bloom encoding %v for column %s, got %v", bloomFilterEncodingUTF8, column, encoding.GetBloomEncoding())
+		}
+		byt, err := ioutil.ReadAll(stream)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var index proto.BloomFilterIndex
+		if err := gproto.Unmarshal(byt, &index); err != nil {
+			t.Fatal(err)
+		}
+		rowIndex, err := c.RowIndex(column)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(index.BloomFilter) != len(rowIndex.Entry) {
+			t.Fatalf("Expected %v bloom filters for column %s, got %v", len(rowIndex.Entry), column, len(index.BloomFilter))
+		}
+		filters := make([]*BloomFilter, len(index.BloomFilter))
+		for i, f := range index.BloomFilter {
+			words := make([]uint64, len(f.Utf8Bitset)/8)
+			for j := range words {
+				words[j] = binary.LittleEndian.Uint64(f.Utf8Bitset[j*8:])
+			}
+			filters[i] = &BloomFilter{
+				numBits:          int32(len(words) * 64),
+				numHashFunctions: int32(f.GetNumHashFunctions()),
+				bitSet:           words,
+			}
+		}
+		stride := int(r.footer.GetRowIndexStride())
+		for i := 0; i < length; i++ {
+			var hash uint64
+			if column == "id" {
+				hash = uint64(integerHash64(int64(i)))
+			} else {
+				hash = murmur3Hash64([]byte(fmt.Sprintf("name-%d", i)))
+			}
+			if !filters[i/stride].testHash(hash) {
+				t.Errorf("Expected bloom filter %v of column %s to contain row %v", i/stride, column, i)
+			}
+		}
+	}
+
+	if _, err := NewWriter(buf, SetSchema(schema), SetBloomFilterColumns([]string{"id"}, 1.5)); err == nil {
+		t.Errorf("Expected an error for an invalid false positive probability")
+	}
+	if _, err := NewWriter(buf, SetSchema(schema), SetBloomFilterColumns([]string{"missing"}, 0.05)); err == nil {
+		t.Errorf("Expected an error for a missing column")
+	}
+}
+
+func TestWriterFlushConcurrency(t *testing.T) {
+	schema, err := ParseSchema("struct<string1:string,int1:int,list1:array<double>,map1:map<string,int>,struct1:struct<int1:int,string1:string>,union1:uniontype<int,string>>")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	length := 25000
+	write := func(fns ...WriterConfigFunc) []byte {
+		buf := &bytes.Buffer{}
+		fns = append(fns, SetSchema(schema), SetCompression(CompressionZlib{}), SetStripeTargetSize(100000))
+		w, err := NewWriter(buf, fns...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < length; i++ {
+			var union interface{} = UnionValue{Tag: 0, Value: int64(i)}
+			if i%2 == 0 {
+				union = UnionValue{Tag: 1, Value: fmt.Sprint(i)}
+			}
+			err = w.Write(
+				fmt.Sprintf("%x", r.Int63n(1000)),
+				r.Int63n(10000),
+				[]float64{r.Float64(), r.Float64()},
+				map[string]int64{fmt.Sprint(i % 10): int64(i)},
+				[]interface{}{int64(i), fmt.Sprint(r.Int63())},
+				union,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		err = w.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+
+	expected := write()
+	actual := write(SetFlushConcurrency(8))
+	if !bytes.Equal(expected, actual) {
+		t.Fatalf("Test failed, expected files written with flush concurrency to be identical")
+	}
+
+	r, err := NewReader(&bytesSizedReaderAt{bytes.NewBuffer(actual)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := r.NumStripes(); err != nil || n < 2 {
+		t.Errorf("Test failed, expected multiple stripes got %v: %v", n, err)
+	}
+	c := r.Select(schema.Columns()...)
+	row := 0
+	for c.Stripes() {
+		for c.Next() {
+			row++
+		}
+	}
+	if err := c.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if row != length {
+		t.Errorf("Test failed, expected %v rows got %v", length, row)
+	}
+
+	if _, err := NewWriter(&bytes.Buffer{}, SetFlushConcurrency(-1)); err == nil {
+		t.Errorf("Test failed, expected an error for an invalid flush concurrency")
+	}
+}
+
+// countingCodec is a CompressionCodec that records the greatest number of its
+// encoders compressing data at the same time.
+type countingCodec struct {
+	CompressionCodec
+	active int32
+	max    int32
+}
+
+func (c *countingCodec) Encoder(w io.Writer) io.WriteCloser {
+	return &countingEncoder{WriteCloser: c.CompressionCodec.Encoder(w), codec: c}
+}
+
+type countingEncoder struct {
+	io.WriteCloser
+	codec   *countingCodec
+	written bool
+}
+
+func (e *countingEncoder) Write(p []byte) (int, error) {
+	e.written = e.written || len(p) > 0
+	return e.WriteCloser.Write(p)
+}
+
+func (e *countingEncoder) Close() error {
+	if !e.written {
+		return e.WriteCloser.Close()
+	}
+	active := atomic.AddInt32(&e.codec.active, 1)
+	defer atomic.AddInt32(&e.codec.active, -1)
+	for {
+		max := atomic.LoadInt32(&e.codec.max)
+		if active <= max || atomic.CompareAndSwapInt32(&e.codec.max, max, active) {
+			break
+		}
+	}
+	time.Sleep(time.Millisecond)
+	return e.WriteCloser.Close()
+}
+
+func TestWriterFlushConcurrencyCompresses(t *testing.T) {
+	schema, err := ParseSchema("struct<int1:int,int2:int,string1:string,double1:double>")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// compress writes a single stripe that fits within the first chunk of each
+	// stream, so that all of its data is compressed when the stripe is closed.
+	compress := func(fns ...WriterConfigFunc) int32 {
+		w, err := NewWriter(&bytes.Buffer{}, append(fns, SetSchema(schema), SetCompression(CompressionZlib{}))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		codec := &countingCodec{CompressionCodec: w.compressionCodec}
+		w.compressionCodec = codec
+		if err := w.initWriters(); err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 100; i++ {
+			if err := w.Write(int64(i), int64(-i), fmt.Sprint(i), float64(i)/3); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return codec.max
+	}
+
+	if max := compress(); max != 1 {
+		t.Errorf("Test failed, expected streams to be compressed one at a time got %v", max)
+	}
+	if max := compress(SetFlushConcurrency(8)); max < 2 {
+		t.Errorf("Test failed, expected streams to be compressed concurrently got %v", max)
+	}
+}
+
+func TestWriterStatistics(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	schema, err := ParseSchema("struct<double1:double,float1:float,date1:date,bool1:boolean,int1:int>")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Write a stripe every 10 rows so that the file statistics are merged
+	// from those of each stripe, the last of which holds 5 rows.
+	w, err := NewWriter(buf, SetSchema(schema), SetStripeTargetSize(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.footer.RowIndexStride = ptrUint32(10)
+
+	epoch := time.Unix(0, 0).UTC()
+	for i := 0; i < 25; i++ {
+		var float1, int1 interface{}
+		if i%2 == 0 {
+			float1 = float32(i) / 4
+		}
+		if i >= 10 {
+			int1 = int64(i)
+		}
+		err := w.Write(float64(i)-10.5, float1, epoch.AddDate(0, 0, i-5), i%3 == 0, int1)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(&bytesSizedReaderAt{bytes.NewBuffer(buf.Bytes())})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(r.Metadata().GetStripeStats()); n != 3 {
+		t.Fatalf("Test failed, expected 3 stripes got %v", n)
+	}
+
+	stats := r.footer.GetStatistics()
+	double1 := stats[1].GetDoubleStatistics()
+	if double1.GetMinimum() != -10.5 || double1.GetMaximum() != 13.5 || double1.GetSum() != 37.5 {
+		t.Errorf("Test failed, expected double minimum -10.5, maximum 13.5 and sum 37.5 got %v", double1)
+	}
+	float1 := stats[2].GetDoubleStatistics()
+	if float1.GetMinimum() != 0 || float1.GetMaximum() != 6 || float1.GetSum() != 39 {
+		t.Errorf("Test failed, expected float minimum 0, maximum 6 and sum 39 got %v", float1)
+	}
+	if stats[2].GetNumberOfValues() != 13 || !stats[2].GetHasNull() {
+		t.Errorf("Test failed, expected 13 values with nulls got %v", stats[2])
+	}
+	date1 := stats[3].GetDateStatistics()
+	if date1.GetMinimum() != -5 || date1.GetMaximum() != 19 {
+		t.Errorf("Test failed, expected date minimum -5 and maximum 19 got %v", date1)
+	}
+	if count := stats[4].GetBucketStatistics().GetCount(); len(count) != 1 || count[0] != 9 {
+		t.Errorf("Test failed, expected 9 true values got %v", count)
+	}
+	int1 := stats[5].GetIntStatistics()
+	if int1.GetMinimum() != 10 || int1.GetMaximum() != 24 || int1.GetSum() != 255 {
+		t.Errorf("Test failed, expected int minimum 10, maximum 24 and sum 255 got %v", int1)
+	}
+	if !stats[5].GetHasNull() {
+		t.Errorf("Test failed, expected int column to have nulls")
+	}
+
+	// The statistics of each stripe only include its own rows.
+	for i, stripe := range r.Metadata().GetStripeStats() {
+		colStats := stripe.GetColStats()
+		date1 := colStats[3].GetDateStatistics()
+		first, last := int32(i*10-5), int32(i*10+4)
+		if last > 19 {
+			last = 19
+		}
+		if date1.GetMinimum() != first || date1.GetMaximum() != last {
+			t.Errorf("Test failed, expected stripe %v date minimum %v and maximum %v got %v", i, first, last, date1)
+		}
+		if count := colStats[4].GetBucketStatistics().GetCount(); len(count) != 1 || count[0] == 0 || count[0] > 4 {
+			t.Errorf("Test failed, expected stripe %v to have between 1 and 4 true values got %v", i, count)
+		}
+		if int1 := colStats[5].GetIntStatistics(); (i == 0) != (int1.Minimum == nil) {
+			t.Errorf("Test failed, expected stripe %v int minimum to be set only if it has values got %v", i, int1)
+		}
+	}
+}
+
+func TestColumnStatisticsReset(t *testing.T) {
+	categories := []Category{
+		CategoryBoolean,
+		CategoryInt,
+		CategoryDouble,
+		CategoryString,
+		CategoryDate,
+		CategoryTimestamp,
+		CategoryBinary,
+		CategoryDecimal,
+		CategoryStruct,
+	}
+	values := []interface{}{
+		true,
+		int64(1),
+		float64(1),
+		"a",
+		time.Unix(86400, 0).UTC(),
+		time.Unix(1, 0).UTC(),
+		[]byte("a"),
+		NewDecimal(big.NewInt(1), 0),
+		[]interface{}{},
+	}
+	for i, category := range categories {
+		s := NewColumnStatistics(category)
+		s.Add(values[i])
+		s.Add(nil)
+		s.Reset()
+		expected := NewColumnStatistics(category).Statistics()
+		if got := s.Statistics(); !reflect.DeepEqual(got, expected) {
+			t.Errorf("Test failed, expected %s statistics to be reset to %v got %v", category, expected, got)
+		}
+	}
+}